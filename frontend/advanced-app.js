@@ -40,6 +40,9 @@ function advancedSchoolApp() {
             message: ''
         },
         
+        // Live Updates (Server-Sent Events)
+        eventSource: null,
+        
         // Charts
         charts: {
             attendance: null,
@@ -51,6 +54,7 @@ function advancedSchoolApp() {
         // Initialize Application
         init() {
             this.loadAllData();
+            this.connectLiveUpdates();
             this.$nextTick(() => {
                 this.initializeCharts();
             });
@@ -207,6 +211,42 @@ function advancedSchoolApp() {
             }
         },
         
+        // Live Updates
+        connectLiveUpdates() {
+            if (this.eventSource) {
+                this.eventSource.close();
+            }
+            const query = this.selectedClassForAttendance ? `?class_id=${this.selectedClassForAttendance}` : '';
+            this.eventSource = new EventSource(`${this.apiBaseUrl}/events/stream${query}`);
+            
+            ['student_lesson.created', 'student_lesson.updated', 'student_lesson.deleted'].forEach(type => {
+                this.eventSource.addEventListener(type, (e) => {
+                    this.applyLiveUpdate('studentLessons', type, JSON.parse(e.data));
+                });
+            });
+            ['lesson_log.created', 'lesson_log.updated', 'lesson_log.deleted'].forEach(type => {
+                this.eventSource.addEventListener(type, (e) => {
+                    this.applyLiveUpdate('lessonLogs', type, JSON.parse(e.data));
+                });
+            });
+            // EventSource reconnects by itself; nothing else to do on error
+        },
+        
+        applyLiveUpdate(collection, type, message) {
+            const item = message.data;
+            if (!item) {
+                // Payload was too large to push; refetch instead
+                if (collection === 'studentLessons') this.loadStudentLessons();
+                return;
+            }
+            const items = this[collection].filter(existing => existing.id !== item.id);
+            if (!type.endsWith('.deleted')) {
+                items.push(item);
+                items.sort((a, b) => a.id - b.id);
+            }
+            this[collection] = items;
+        },
+        
         // Utility Functions
         getSubjectName(subjectId) {
             const subject = this.subjects.find(s => s.id === subjectId);
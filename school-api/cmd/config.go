@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"school-api/internal/config"
+)
+
+// configCommand handles "config print": it writes the effective
+// configuration as YAML with secrets redacted and exits non-zero when it
+// does not validate.
+func configCommand(args []string) {
+	if len(args) == 0 || args[0] != "print" {
+		log.Fatal("usage: school-api config print [-f config.yaml]")
+	}
+	fs := flag.NewFlagSet("config print", flag.ExitOnError)
+	file := fs.String("f", "", "YAML config file (default $"+config.FileEnv+")")
+	fs.Parse(args[1:])
+
+	cfg, err := config.Load(*file)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if err := config.Print(os.Stdout, cfg); err != nil {
+		log.Fatalf("Failed to print configuration: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -1,79 +1,330 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
-	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
 
+	"school-api/internal/apikey"
+	"school-api/internal/auth"
+	"school-api/internal/cache"
+	"school-api/internal/config"
 	dbpkg "school-api/internal/db"
-	"school-api/internal/models"
+	"school-api/internal/events"
+	"school-api/internal/grpcapi"
+	"school-api/internal/logging"
+	"school-api/internal/metrics"
+	"school-api/internal/notify"
+	"school-api/internal/ratelimit"
 	"school-api/internal/router"
+	"school-api/internal/storage"
+	"school-api/internal/stream"
+	"school-api/internal/tenant"
+	"school-api/internal/tracing"
+	"school-api/internal/webhooks"
 )
 
-// CORS middleware для всех запросов
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Max-Age", "3600")
-
-		// Preflight запросы (OPTIONS) просто возвращаем 200
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+func main() {
+	// Подкоманда выпуска токена: school-api token -role guardian -guardian-id 1
+	if len(os.Args) > 1 && os.Args[1] == "token" {
+		issueToken(os.Args[2:])
+		return
+	}
 
-		next.ServeHTTP(w, r)
-	})
-}
+	// Выгрузка OpenAPI-документа: school-api openapi -o openapi.json
+	if len(os.Args) > 1 && os.Args[1] == "openapi" {
+		writeOpenAPI(os.Args[2:])
+		return
+	}
 
-func main() {
-	// Загружаем .env (локально)
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, continuing...")
+	// Управление школами: school-api school add -name "Школа №1" -slug school1
+	if len(os.Args) > 1 && os.Args[1] == "school" {
+		schoolCommand(os.Args[2:])
+		return
+	}
+
+	// Просмотр итоговой конфигурации (секреты скрыты): school-api config print
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		configCommand(os.Args[2:])
+		return
+	}
+
+	// Конфигурация: значения по умолчанию, YAML (CONFIG_FILE), .env и окружение
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Структурированные логи (slog)
+	logger, err := logging.New(os.Stdout, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		log.Fatalf("Failed to init logging: %v", err)
+	}
+	slog.SetDefault(logger)
+	if err := cfg.Validate(); err != nil {
+		fatal("Invalid configuration", "error", err)
+	}
+
+	// Контекст процесса отменяется по SIGTERM/SIGINT (остановка контейнера)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	// Трассировка OpenTelemetry (экспорт по OTLP, если задан OTEL_EXPORTER_OTLP_ENDPOINT)
+	shutdownTracing, err := tracing.Setup(ctx)
+	if err != nil {
+		fatal("Failed to init tracing", "error", err)
 	}
 
 	// Подключение к базе данных
-	db, err := dbpkg.Connect()
+	db, err := dbpkg.Connect(cfg.DB)
+	if err != nil {
+		fatal("Failed to connect to database", "error", err)
+	}
+
+	// Миграции схемы (AutoMigrate + версионированные миграции)
+	if err := dbpkg.Migrate(db); err != nil {
+		fatal("Migration failed", "error", err)
+	}
+
+	// Метрики Prometheus (/metrics)
+	var m *metrics.Metrics
+	if cfg.Features.Metrics {
+		m, err = metrics.New(db)
+		if err != nil {
+			fatal("Failed to init metrics", "error", err)
+		}
+	}
+
+	// Хранилище файлов (локальная ФС или S3-совместимое)
+	files, limits, err := storage.New(cfg.Storage)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("Failed to init file storage", "error", err)
+	}
+
+	// Фоновые обработчики живут в отдельном контексте: их останавливаем
+	// только после того, как серверы дообработали запросы
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	runWorker := func(run func(context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workerCtx)
+		}()
+	}
+
+	// Шина событий журнала и доставка вебхуков
+	bus := events.NewBus()
+	if cfg.Features.Webhooks {
+		dispatcher := webhooks.NewDispatcher(db, cfg.Webhooks.Networks())
+		bus.Subscribe(dispatcher.Enqueue)
+		runWorker(dispatcher.Run)
+	}
+
+	// Живые обновления журнала (SSE) через Postgres LISTEN/NOTIFY
+	hub := stream.NewHub()
+	if cfg.Features.LiveUpdates {
+		bus.Subscribe(stream.Notifier(db))
+		runWorker(func(ctx context.Context) { stream.Listen(ctx, cfg.DB.ConnString(), hub) })
 	}
 
-	// Миграция моделей
-	if err := db.AutoMigrate(
-		&models.Class{},
-		&models.Student{},
-		&models.Teacher{},
-		&models.Subject{},
-		&models.TeacherAssignment{},
-		&models.LessonSchedule{},
-		&models.LessonLog{},
-		&models.StudentLesson{},
-		&models.AttendanceStatus{},
-	); err != nil {
-		log.Fatalf("Auto migrate failed: %v", err)
+	// Уведомления родителям по email (включаются заданием SMTP_HOST)
+	if cfg.SMTP.Host != "" {
+		mailer := notify.SMTPMailer{Config: notify.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			User:     cfg.SMTP.User,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+		}}
+		notifier, err := notify.New(db, mailer, cfg.Notify.LowGradeThreshold, cfg.Notify.DigestHour)
+		if err != nil {
+			fatal("Failed to init notifier", "error", err)
+		}
+		bus.Subscribe(notifier.Enqueue)
+		runWorker(notifier.Run)
+	}
+
+	// Ограничение частоты запросов (память процесса или общая таблица в Postgres)
+	authn := auth.Authenticator{
+		Issuer: auth.Issuer{Secret: []byte(cfg.Auth.Secret)},
+		Keys:   apikey.Verifier{DB: db},
+	}
+	var limiter *ratelimit.Limiter
+	if cfg.Features.RateLimit {
+		var store ratelimit.Store = ratelimit.NewMemory()
+		if cfg.RateLimit.Backend == "postgres" {
+			store = ratelimit.Postgres{DB: db}
+		}
+		limiter = ratelimit.New(store, cfg.RateLimit, authn)
+		runWorker(limiter.Run)
+	}
+
+	// Кэш справочников: статусы посещаемости, предметы и классы
+	var refCache *cache.Cache
+	if cfg.Features.Cache {
+		var store cache.Store = cache.NewMemory()
+		if cfg.Cache.Backend == "postgres" {
+			store = cache.Postgres{DB: db}
+		}
+		refCache = cache.New(store, cfg.Cache)
+		runWorker(refCache.Run)
+	}
+
+	// Школа запроса: из токена, поддомена или школа по умолчанию
+	schools := tenant.Resolver{
+		DB:         db,
+		BaseDomain: cfg.Tenant.BaseDomain,
+		Default:    uint(cfg.Tenant.DefaultSchool),
 	}
 
 	// Настройка маршрутов
-	r := router.Setup(db)
+	r := router.Setup(router.Deps{
+		DB:             db,
+		Auth:           authn,
+		RequireAuth:    cfg.Auth.Required,
+		Files:          files,
+		UploadLimits:   limits,
+		Events:         bus,
+		Stream:         hub,
+		Metrics:        m,
+		Features:       cfg.Features,
+		CORS:           cfg.CORS,
+		TrustedProxies: cfg.HTTP.TrustedProxies,
+		RateLimit:      limiter,
+		Tenant:         schools,
+		Cache:          refCache,
 
-	// Оборачиваем маршрутизатор в CORS middleware
-	handler := corsMiddleware(r)
+		WebhookNetworks: cfg.Webhooks.Networks(),
+	})
 
-	// Получаем порт из окружения (Serverless Containers)
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8000" // fallback для локальной разработки
+	// gRPC API в том же процессе на отдельном порту
+	var grpcServer *grpc.Server
+	if cfg.Features.GRPC {
+		grpcAddr := ":" + cfg.GRPC.Port
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			fatal("Failed to listen", "addr", grpcAddr, "error", err)
+		}
+		grpcServer = grpcapi.NewServer(db, bus, refCache,
+			append(grpcapi.Auth(authn, cfg.Auth.Required, limiter), grpcapi.Tenant(schools)...)...)
+		go func() {
+			slog.Info("gRPC server listening", "addr", grpcAddr)
+			if err := grpcServer.Serve(lis); err != nil {
+				fatal("gRPC server failed", "error", err)
+			}
+		}()
 	}
+
+	// Порт из конфигурации (PORT задаёт платформа Serverless Containers)
+	port := cfg.HTTP.Port
 	addr := ":" + port
 
-	log.Printf("Server listening on port %s", port)
+	// HTTP сервер с таймаутами. WriteTimeout не действует на SSE: поток
+	// снимает дедлайн сам и завершается при остановке сервера.
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           r,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+	srv.RegisterOnShutdown(hub.Close)
 
 	// Запуск HTTP сервера
-	if err := http.ListenAndServe(addr, handler); err != nil {
-		log.Fatalf("Server failed: %v", err)
+	go func() {
+		slog.Info("Server listening", "port", port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("Server failed", "error", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	timeout := cfg.HTTP.ShutdownTimeout
+	slog.Info("Shutting down", "timeout", timeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// 1. Перестаём принимать запросы и дожидаемся текущих (HTTP и gRPC)
+	var servers sync.WaitGroup
+	servers.Add(2)
+	go func() {
+		defer servers.Done()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Error("HTTP server shutdown incomplete", "error", err)
+		}
+	}()
+	go func() {
+		defer servers.Done()
+		stopGRPC(shutdownCtx, grpcServer)
+	}()
+	servers.Wait()
+
+	// 2. Останавливаем фоновые обработчики
+	stopWorkers()
+	if !waitTimeout(shutdownCtx, &workers) {
+		slog.Error("Background workers did not stop in time")
+	}
+
+	// 3. Отправляем накопленные спаны и закрываем пул соединений
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Tracing shutdown failed", "error", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			slog.Error("Closing database pool failed", "error", err)
+		}
+	}
+	slog.Info("Shutdown complete")
+}
+
+// stopGRPC lets running RPCs finish and cancels them once ctx expires.
+// A nil server (gRPC disabled) is ignored.
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	if s == nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Error("gRPC server shutdown incomplete", "error", ctx.Err())
+		s.Stop()
+	}
+}
+
+// waitTimeout waits for wg and reports false if ctx expired first.
+func waitTimeout(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
 	}
 }
+
+// fatal logs at error level and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
@@ -0,0 +1,40 @@
+package main
+
+//go:generate go run . openapi -o ../../api-docs/swagger/openapi.json
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"school-api/internal/router"
+)
+
+// writeOpenAPI writes the OpenAPI document generated from the route table,
+// the same one the server serves at /openapi.json.
+func writeOpenAPI(args []string) {
+	fs := flag.NewFlagSet("openapi", flag.ExitOnError)
+	out := fs.String("o", "", "output file (stdout when empty)")
+	fs.Parse(args)
+	gin.SetMode(gin.ReleaseMode)
+
+	doc, err := router.OpenAPI()
+	if err != nil {
+		log.Fatalf("Failed to build OpenAPI document: %v", err)
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode OpenAPI document: %v", err)
+	}
+	b = append(b, '\n')
+	if *out == "" {
+		os.Stdout.Write(b)
+		return
+	}
+	if err := os.WriteFile(*out, b, 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+}
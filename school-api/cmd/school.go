@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"text/tabwriter"
+
+	"school-api/internal/config"
+	dbpkg "school-api/internal/db"
+	"school-api/internal/models"
+)
+
+// slugPattern is a DNS label, since the slug is used as a subdomain.
+var slugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// schoolCommand handles "school add" and "school list". Schools are
+// provisioned by the operator; the API only shows the caller's own.
+func schoolCommand(args []string) {
+	if len(args) == 0 || (args[0] != "add" && args[0] != "list") {
+		log.Fatal("usage: school-api school add -name <name> -slug <slug> | school-api school list")
+	}
+	fs := flag.NewFlagSet("school "+args[0], flag.ExitOnError)
+	name := fs.String("name", "", "school name")
+	slug := fs.String("slug", "", "subdomain of the school, e.g. school1")
+	fs.Parse(args[1:])
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	db, err := dbpkg.Connect(cfg.DB)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	if err := dbpkg.Migrate(db); err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	if args[0] == "add" {
+		if *name == "" || !slugPattern.MatchString(*slug) {
+			log.Fatal("-name and a lowercase -slug of letters, digits and dashes are required")
+		}
+		s := models.School{Name: *name, Slug: *slug}
+		if err := db.Create(&s).Error; err != nil {
+			log.Fatalf("Failed to add school: %v", err)
+		}
+		fmt.Println(s.ID)
+		return
+	}
+	var schools []models.School
+	if err := db.Order("id").Find(&schools).Error; err != nil {
+		log.Fatalf("Failed to list schools: %v", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSLUG\tNAME")
+	for _, s := range schools {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.ID, s.Slug, s.Name)
+	}
+	w.Flush()
+}
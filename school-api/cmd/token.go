@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"school-api/internal/auth"
+	"school-api/internal/config"
+)
+
+// issueToken prints a signed bearer token, e.g. for a guardian account
+// provisioned outside the API.
+func issueToken(args []string) {
+	fs := flag.NewFlagSet("token", flag.ExitOnError)
+	subject := fs.String("sub", "", "subject (user identifier)")
+	role := fs.String("role", auth.RoleGuardian, "role: admin, teacher, guardian or operator")
+	guardianID := fs.Uint("guardian-id", 0, "guardian ID for the guardian role")
+	schoolID := fs.Uint("school-id", 0, "school the token is valid for; 0 for the default school")
+	ttl := fs.Duration("ttl", 30*24*time.Hour, "token lifetime")
+	fs.Parse(args)
+
+	if *role == auth.RoleGuardian && *guardianID == 0 {
+		log.Fatal("-guardian-id is required for the guardian role")
+	}
+	if *subject == "" {
+		*subject = fmt.Sprintf("%s:%d", *role, *guardianID)
+	}
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if cfg.Auth.Secret == "" {
+		log.Fatal("auth.secret (AUTH_SECRET) is not set")
+	}
+	issuer := auth.Issuer{Secret: []byte(cfg.Auth.Secret)}
+	token, err := issuer.Sign(auth.Principal{Subject: *subject, Role: *role, GuardianID: uint(*guardianID), SchoolID: uint(*schoolID)}, *ttl)
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+	fmt.Println(token)
+}
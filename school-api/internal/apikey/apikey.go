@@ -0,0 +1,96 @@
+// Package apikey issues and verifies API keys for machine clients such as
+// reporting scripts and the school information system.
+//
+// A key looks like sch_1a2b3c4d_<secret>. The sch_1a2b3c4d prefix is
+// stored in clear to find the key; the whole key is stored only as a
+// SHA-256 hash, which is enough for 256 random bits.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/auth"
+	"school-api/internal/models"
+	"school-api/internal/tenant"
+)
+
+const keyPrefix = "sch_"
+
+// prefixLen is the length of the lookup prefix: keyPrefix and 8 hex digits.
+const prefixLen = len(keyPrefix) + 8
+
+// Generate returns a new key, its lookup prefix and the hash to store.
+func Generate() (key, prefix, hash string, err error) {
+	id := make([]byte, 4)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+	prefix = keyPrefix + hex.EncodeToString(id)
+	key = prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return key, prefix, Hash(key), nil
+}
+
+// Hash returns the stored form of key.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ErrInvalid is returned for an unknown, revoked or expired key.
+var ErrInvalid = errors.New("invalid API key")
+
+// lastUsedEvery limits how often a key's last_used_at is written.
+const lastUsedEvery = time.Minute
+
+// Verifier checks keys against the api_keys table and implements
+// auth.KeyVerifier.
+type Verifier struct {
+	DB *gorm.DB
+}
+
+func (v Verifier) VerifyKey(ctx context.Context, key string) (auth.Principal, error) {
+	if len(key) <= prefixLen+1 || !strings.HasPrefix(key, keyPrefix) || key[prefixLen] != '_' {
+		return auth.Principal{}, ErrInvalid
+	}
+	// The key tells the school, so it is looked up in all of them.
+	ctx = tenant.AllSchools(ctx)
+	var k models.APIKey
+	err := v.DB.WithContext(ctx).Where("prefix = ?", key[:prefixLen]).Take(&k).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return auth.Principal{}, ErrInvalid
+	}
+	if err != nil {
+		return auth.Principal{}, err
+	}
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(k.Hash), []byte(Hash(key))) != 1 ||
+		k.RevokedAt != nil || (k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)) {
+		return auth.Principal{}, ErrInvalid
+	}
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= lastUsedEvery {
+		if err := v.DB.WithContext(ctx).Model(&k).UpdateColumn("last_used_at", now).Error; err != nil {
+			return auth.Principal{}, err
+		}
+	}
+	return auth.Principal{
+		Subject:  fmt.Sprintf("apikey:%d", k.ID),
+		Role:     auth.RoleService,
+		SchoolID: k.SchoolID,
+		Scopes:   strings.Split(k.Scopes, ","),
+	}, nil
+}
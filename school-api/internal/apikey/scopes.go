@@ -0,0 +1,89 @@
+package apikey
+
+import (
+	"net/http"
+	"strings"
+)
+
+// resources group the API routes by their first path segment after
+// /api/v1. Routes missing here (the parent portal, key management) are
+// closed to API keys.
+var resources = map[string]string{
+	"school":              "school",
+	"classes":             "school",
+	"students":            "school",
+	"teachers":            "school",
+	"subjects":            "school",
+	"guardians":           "school",
+	"rollover":            "school",
+	"duplicates":          "school",
+	"search":              "school",
+	"lesson-schedules":    "schedule",
+	"teacher-assignments": "schedule",
+	"lesson-logs":         "journal",
+	"student-lessons":     "journal",
+	"attendance-statuses": "journal",
+	"events":              "journal",
+	"homework":            "homework",
+	"submissions":         "homework",
+	"webhooks":            "webhooks",
+	"graphql":             "graphql",
+}
+
+// services group the gRPC services like resources group the routes.
+// Services missing here are closed to API keys.
+var services = map[string]string{
+	"journal.v1.ClassService":             "school",
+	"journal.v1.StudentService":           "school",
+	"journal.v1.TeacherService":           "school",
+	"journal.v1.SubjectService":           "school",
+	"journal.v1.TeacherAssignmentService": "schedule",
+	"journal.v1.LessonScheduleService":    "schedule",
+	"journal.v1.LessonLogService":         "journal",
+	"journal.v1.StudentLessonService":     "journal",
+	"journal.v1.AttendanceStatusService":  "journal",
+}
+
+// Scopes are all scopes a key can be granted: read:<resource> for GET and
+// HEAD, write:<resource> for the other methods. GraphQL is read-only.
+var Scopes = []string{
+	"read:school", "write:school",
+	"read:schedule", "write:schedule",
+	"read:journal", "write:journal",
+	"read:homework", "write:homework",
+	"read:webhooks", "write:webhooks",
+	"read:graphql",
+}
+
+// ScopeFor returns the scope required for a gin route, or "" when API
+// keys may not use it.
+func ScopeFor(method, path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/v1/")
+	if !ok {
+		return ""
+	}
+	segment, _, _ := strings.Cut(rest, "/")
+	resource, ok := resources[segment]
+	if !ok {
+		return ""
+	}
+	if method == http.MethodGet || method == http.MethodHead || resource == "graphql" {
+		return "read:" + resource
+	}
+	return "write:" + resource
+}
+
+// ScopeForMethod returns the scope required for a gRPC method, given as
+// "/package.Service/Method", or "" when API keys may not call it. write
+// tells whether the method changes data.
+func ScopeForMethod(fullMethod string, write bool) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	resource, ok := services[service]
+	if !ok {
+		return ""
+	}
+	if write {
+		return "write:" + resource
+	}
+	return "read:" + resource
+}
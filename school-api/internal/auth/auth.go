@@ -0,0 +1,144 @@
+// Package auth verifies bearer tokens and exposes the calling principal
+// to handlers.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+
+	"school-api/internal/logging"
+)
+
+// Roles a principal can have.
+const (
+	RoleAdmin    = "admin"
+	RoleTeacher  = "teacher"
+	RoleGuardian = "guardian"
+	// RoleOperator runs the deployment for every school. It alone may
+	// change the data shared by all schools, such as attendance statuses.
+	RoleOperator = "operator"
+)
+
+const principalKey = "auth.principal"
+
+// Principal is the authenticated caller.
+type Principal struct {
+	Subject    string `json:"sub"`
+	Role       string `json:"role"`
+	GuardianID uint   `json:"guardian_id,omitempty"`
+	// SchoolID is the school the caller belongs to; 0 for tokens issued
+	// before schools were introduced.
+	SchoolID uint `json:"school_id,omitempty"`
+	// Scopes limit an API key principal, e.g. "read:journal".
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type claims struct {
+	Role       string `json:"role"`
+	GuardianID uint   `json:"guardian_id,omitempty"`
+	SchoolID   uint   `json:"school_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Issuer signs and verifies HS256 tokens with a shared secret.
+type Issuer struct {
+	Secret []byte
+}
+
+// Sign returns a token for p valid for ttl.
+func (i Issuer) Sign(p Principal, ttl time.Duration) (string, error) {
+	if len(i.Secret) == 0 {
+		return "", errors.New("auth secret is not configured")
+	}
+	now := time.Now()
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Role:       p.Role,
+		GuardianID: p.GuardianID,
+		SchoolID:   p.SchoolID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   p.Subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	})
+	return tok.SignedString(i.Secret)
+}
+
+// Verify parses a token and returns its principal.
+func (i Issuer) Verify(token string) (Principal, error) {
+	if len(i.Secret) == 0 {
+		return Principal{}, errors.New("auth secret is not configured")
+	}
+	var cl claims
+	_, err := jwt.ParseWithClaims(token, &cl, func(*jwt.Token) (any, error) {
+		return i.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{Subject: cl.Subject, Role: cl.Role, GuardianID: cl.GuardianID, SchoolID: cl.SchoolID}, nil
+}
+
+// RequireRole aborts with 403 unless the principal has one of roles.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := FromContext(c)
+		if ok {
+			for _, r := range roles {
+				if p.Role == r {
+					c.Next()
+					return
+				}
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden", "message": fmt.Sprintf("Requires role: %s", strings.Join(roles, ", ")), "request_id": logging.RequestID(c.Request.Context())})
+	}
+}
+
+// StaffRoles may use the API outside the parent portal.
+var StaffRoles = []string{RoleAdmin, RoleTeacher, RoleService}
+
+// RestrictRoles aborts with 403 when the principal has none of roles.
+// Unlike RequireRole it lets anonymous callers through; whether they are
+// admitted is up to Authenticator.Middleware.
+func RestrictRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if p, ok := FromContext(c); ok && !slices.Contains(roles, p.Role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden", "message": fmt.Sprintf("Requires role: %s", strings.Join(roles, ", ")), "request_id": logging.RequestID(c.Request.Context())})
+			return
+		}
+		c.Next()
+	}
+}
+
+type principalCtxKey struct{}
+
+// WithPrincipal returns ctx carrying p, for code outside gin such as the
+// gRPC interceptors.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+// PrincipalFrom returns the principal stored by WithPrincipal.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalCtxKey{}).(Principal)
+	return p, ok
+}
+
+// FromContext returns the principal set by Authenticator.Middleware.
+func FromContext(c *gin.Context) (Principal, bool) {
+	v, ok := c.Get(principalKey)
+	if !ok {
+		return Principal{}, false
+	}
+	p, ok := v.(Principal)
+	return p, ok
+}
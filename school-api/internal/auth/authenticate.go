@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"school-api/internal/logging"
+)
+
+// RoleService is the role of API key principals.
+const RoleService = "service"
+
+// KeyVerifier checks an API key and returns its principal.
+type KeyVerifier interface {
+	VerifyKey(ctx context.Context, key string) (Principal, error)
+}
+
+// Authenticator accepts "Authorization: Bearer <token>" and, when Keys is
+// set, "Authorization: ApiKey <key>".
+type Authenticator struct {
+	Issuer Issuer
+	Keys   KeyVerifier
+}
+
+const identityKey = "auth.identity"
+
+type identity struct {
+	principal Principal
+	ok        bool
+	err       error
+}
+
+// errNoKeys rejects API keys when no KeyVerifier is configured.
+var errNoKeys = errors.New("API keys are not accepted")
+
+// Identify returns the principal of the request's credentials, ok=false
+// when it has none, or the reason they were rejected. The result is kept
+// on c so that credentials are verified once per request.
+func (a Authenticator) Identify(c *gin.Context) (Principal, bool, error) {
+	if v, ok := c.Get(identityKey); ok {
+		id := v.(identity)
+		return id.principal, id.ok, id.err
+	}
+	var id identity
+	id.principal, id.ok, id.err = a.Credentials(c.Request.Context(), c.GetHeader("Authorization"))
+	c.Set(identityKey, id)
+	return id.principal, id.ok, id.err
+}
+
+// Credentials returns the principal of an Authorization value, "Bearer
+// <token>" or "ApiKey <key>", with ok=false when it is empty. The gRPC
+// API passes its "authorization" metadata here.
+func (a Authenticator) Credentials(ctx context.Context, authorization string) (Principal, bool, error) {
+	scheme, cred, _ := strings.Cut(authorization, " ")
+	var p Principal
+	var err error
+	switch {
+	case cred == "":
+		return Principal{}, false, nil
+	case strings.EqualFold(scheme, "Bearer"):
+		p, err = a.Issuer.Verify(cred)
+	case strings.EqualFold(scheme, "ApiKey"):
+		if a.Keys == nil {
+			return Principal{}, false, errNoKeys
+		}
+		p, err = a.Keys.VerifyKey(ctx, cred)
+	default:
+		return Principal{}, false, nil
+	}
+	return p, err == nil, err
+}
+
+// Middleware stores the principal for FromContext. Invalid credentials
+// are answered with 401; missing ones too when required is set.
+func (a Authenticator) Middleware(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok, err := a.Identify(c)
+		switch {
+		case err != nil:
+			unauthorized(c, "Invalid credentials")
+		case !ok && required:
+			unauthorized(c, "Bearer token or API key required")
+		default:
+			if ok {
+				c.Set(principalKey, p)
+			}
+			c.Next()
+		}
+	}
+}
+
+func unauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer, ApiKey`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized", "message": message, "request_id": logging.RequestID(c.Request.Context())})
+}
+
+// RequireScope aborts with 403 when an API key principal lacks the scope
+// scopeFor returns for the route. Other principals and anonymous callers
+// pass; roles govern them.
+func RequireScope(scopeFor func(method, path string) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p, ok := FromContext(c)
+		if !ok || p.Role != RoleService {
+			c.Next()
+			return
+		}
+		scope := scopeFor(c.Request.Method, c.FullPath())
+		if scope == "" || !p.HasScope(scope) {
+			msg := "API keys cannot access this route"
+			if scope != "" {
+				msg = "Requires scope: " + scope
+			}
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden", "message": msg, "request_id": logging.RequestID(c.Request.Context())})
+			return
+		}
+		c.Next()
+	}
+}
+
+// HasScope reports whether p was granted scope.
+func (p Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
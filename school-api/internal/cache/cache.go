@@ -0,0 +1,150 @@
+// Package cache keeps the API responses for reference data that is read
+// on nearly every page but rarely changes: attendance statuses, subjects
+// and classes. Entries expire after a TTL and are dropped whenever the
+// resource is written, so a change is visible at once on the instance
+// that made it, and on all of them with a shared Store.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"school-api/internal/config"
+	"school-api/internal/tenant"
+)
+
+// Cached resources, named after their API paths.
+const (
+	AttendanceStatuses = "attendance-statuses"
+	Subjects           = "subjects"
+	Classes            = "classes"
+)
+
+// shared resources are the same for every school.
+var shared = map[string]bool{AttendanceStatuses: true}
+
+// Entry is a cached JSON body and the time it was stored, which serves as
+// its Last-Modified time.
+type Entry struct {
+	Body     []byte
+	Modified time.Time
+}
+
+// Store keeps the entries.
+type Store interface {
+	// Get returns the entry at key unless it is missing or expired.
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	// Set stores e at key for ttl.
+	Set(ctx context.Context, key string, e Entry, ttl time.Duration) error
+	// DeletePrefix removes every entry whose key starts with prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+	// Sweep removes expired entries.
+	Sweep(ctx context.Context) error
+}
+
+// Cache reads through and writes through a Store. Store failures are
+// logged and otherwise ignored: the cache never fails a request.
+type Cache struct {
+	Store Store
+	TTL   time.Duration
+	// MaxAge is the Cache-Control max-age of cached responses.
+	MaxAge time.Duration
+}
+
+// New returns a cache with the settings of cfg.
+func New(store Store, cfg config.Cache) *Cache {
+	return &Cache{Store: store, TTL: cfg.TTL, MaxAge: cfg.MaxAge}
+}
+
+// prefix is the key prefix of resource in the school of ctx. The
+// trailing colon keeps school 1 from matching school 12.
+func prefix(ctx context.Context, resource string) string {
+	if id, ok := tenant.FromContext(ctx); ok && !shared[resource] {
+		return fmt.Sprintf("%s:%d:", resource, id)
+	}
+	return resource + ":"
+}
+
+// ListKey is the key of resource's list in the school of ctx.
+func ListKey(ctx context.Context, resource string) string {
+	return prefix(ctx, resource) + "list"
+}
+
+// ItemKey is the key of one item of resource in the school of ctx.
+func ItemKey(ctx context.Context, resource, id string) string {
+	return prefix(ctx, resource) + "item:" + id
+}
+
+// Load returns the entry at key. On a miss it stores the JSON of the value
+// load returns; errors from load are returned and nothing is stored.
+func (c *Cache) Load(ctx context.Context, key string, load func() (any, error)) (Entry, error) {
+	e, ok, err := c.Store.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "cache: get failed", "key", key, "error", err)
+	}
+	if ok {
+		return e, nil
+	}
+	v, err := load()
+	if err != nil {
+		return Entry{}, err
+	}
+	return c.Put(ctx, key, v)
+}
+
+// Put stores the JSON of v at key, e.g. an item that has just been
+// written, and returns the entry.
+func (c *Cache) Put(ctx context.Context, key string, v any) (Entry, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return Entry{}, err
+	}
+	e := Entry{Body: body, Modified: time.Now()}
+	if err := c.Store.Set(ctx, key, e, c.TTL); err != nil {
+		slog.WarnContext(ctx, "cache: set failed", "key", key, "error", err)
+	}
+	return e, nil
+}
+
+// Invalidate drops the list and every item of resource in the school of
+// ctx. It does nothing on a nil Cache, so writers need not check.
+func (c *Cache) Invalidate(ctx context.Context, resource string) {
+	if c == nil {
+		return
+	}
+	if err := c.Store.DeletePrefix(ctx, prefix(ctx, resource)); err != nil {
+		slog.ErrorContext(ctx, "cache: invalidation failed", "resource", resource, "error", err)
+	}
+}
+
+// CacheControl is the Cache-Control header of cached responses. They
+// depend on the caller's school and credentials, so only the browser may
+// keep them.
+func (c *Cache) CacheControl() string {
+	if c.MaxAge <= 0 {
+		return "private, no-cache"
+	}
+	return fmt.Sprintf("private, max-age=%d", int(c.MaxAge.Seconds()))
+}
+
+// sweepInterval is how often Run removes expired entries.
+const sweepInterval = time.Minute
+
+// Run sweeps expired entries until ctx is cancelled.
+func (c *Cache) Run(ctx context.Context) {
+	t := time.NewTicker(sweepInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := c.Store.Sweep(ctx); err != nil && ctx.Err() == nil {
+				slog.Warn("cache: sweep failed", "error", err)
+			}
+		}
+	}
+}
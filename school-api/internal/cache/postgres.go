@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"school-api/internal/models"
+)
+
+// Postgres keeps entries in the cache_entries table, so that all API
+// instances share them and see each other's invalidations.
+type Postgres struct {
+	DB *gorm.DB
+}
+
+func (p Postgres) Get(ctx context.Context, key string) (Entry, bool, error) {
+	var row models.CacheEntry
+	err := p.DB.WithContext(ctx).Where("key = ? AND expires_at > now()", key).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	return Entry{Body: row.Body, Modified: row.Modified}, true, nil
+}
+
+func (p Postgres) Set(ctx context.Context, key string, e Entry, ttl time.Duration) error {
+	row := models.CacheEntry{Key: key, Body: e.Body, Modified: e.Modified, ExpiresAt: time.Now().Add(ttl)}
+	return p.DB.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&row).Error
+}
+
+// likeEscaper quotes the LIKE wildcards in a prefix.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (p Postgres) DeletePrefix(ctx context.Context, prefix string) error {
+	return p.DB.WithContext(ctx).Where("key LIKE ?", likeEscaper.Replace(prefix)+"%").
+		Delete(&models.CacheEntry{}).Error
+}
+
+func (p Postgres) Sweep(ctx context.Context) error {
+	return p.DB.WithContext(ctx).Where("expires_at <= now()").Delete(&models.CacheEntry{}).Error
+}
@@ -0,0 +1,299 @@
+// Package config holds the typed server configuration. Values come from
+// the struct defaults, an optional YAML file, a .env file and the
+// environment, later sources overriding earlier ones, and are validated
+// once at startup.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is the complete server configuration. Each field names its YAML
+// key, its environment variable and its default; fields tagged secret are
+// redacted when printed.
+type Config struct {
+	HTTP      HTTP      `yaml:"http"`
+	GRPC      GRPC      `yaml:"grpc"`
+	DB        DB        `yaml:"db"`
+	CORS      CORS      `yaml:"cors"`
+	RateLimit RateLimit `yaml:"rate_limit"`
+	Cache     Cache     `yaml:"cache"`
+	Log       Log       `yaml:"log"`
+	Auth      Auth      `yaml:"auth"`
+	Tenant    Tenant    `yaml:"tenant"`
+	Storage   Storage   `yaml:"storage"`
+	SMTP      SMTP      `yaml:"smtp"`
+	Notify    Notify    `yaml:"notify"`
+	Webhooks  Webhooks  `yaml:"webhooks"`
+	Features  Features  `yaml:"features"`
+}
+
+type HTTP struct {
+	Port              string        `yaml:"port" env:"PORT" default:"8000"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT" default:"10s"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT" default:"2m"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" default:"2m"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT" default:"2m"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"25s"`
+	// TrustedProxies are the addresses or CIDR ranges whose X-Forwarded-For
+	// header is believed when determining the client IP.
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+}
+
+type GRPC struct {
+	Port string `yaml:"port" env:"GRPC_PORT" default:"9090"`
+}
+
+// DB is the Postgres connection. DSN, when set, is used as is instead of
+// the individual fields.
+type DB struct {
+	DSN             string        `yaml:"dsn" env:"DB_DSN" secret:"true"`
+	Host            string        `yaml:"host" env:"DB_HOST"`
+	Port            string        `yaml:"port" env:"DB_PORT" default:"5432"`
+	User            string        `yaml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name            string        `yaml:"name" env:"DB_NAME"`
+	SSLMode         string        `yaml:"sslmode" env:"DB_SSLMODE" default:"disable"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" default:"20"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" default:"5"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" default:"30m"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME" default:"5m"`
+}
+
+// ConnString returns the connection string for pgx.
+func (d DB) ConnString() string {
+	if d.DSN != "" {
+		return d.DSN
+	}
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		d.Host, d.Port, d.User, d.Password, d.Name, d.SSLMode,
+	)
+}
+
+// CORS lists the browser origins allowed to call the API. An entry is
+// "*", scheme://host[:port] or scheme://*.host[:port] for subdomains.
+type CORS struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" default:"*"`
+	AllowCredentials bool          `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+	MaxAge           time.Duration `yaml:"max_age" env:"CORS_MAX_AGE" default:"1h"`
+}
+
+// RateLimit sets the per-client token buckets. Reads (GET, HEAD) and
+// writes have separate budgets: a client may make Burst requests at once
+// and PerMinute on average.
+type RateLimit struct {
+	Backend        string `yaml:"backend" env:"RATE_LIMIT_BACKEND" default:"memory"`
+	ReadPerMinute  int    `yaml:"read_per_minute" env:"RATE_LIMIT_READ_PER_MINUTE" default:"300"`
+	ReadBurst      int    `yaml:"read_burst" env:"RATE_LIMIT_READ_BURST" default:"100"`
+	WritePerMinute int    `yaml:"write_per_minute" env:"RATE_LIMIT_WRITE_PER_MINUTE" default:"60"`
+	WriteBurst     int    `yaml:"write_burst" env:"RATE_LIMIT_WRITE_BURST" default:"20"`
+}
+
+// Cache keeps attendance statuses, subjects and classes. The memory
+// backend is per instance: after a change other instances may serve the
+// old data for up to TTL. The postgres backend is shared by all of them.
+type Cache struct {
+	Backend string        `yaml:"backend" env:"CACHE_BACKEND" default:"memory"`
+	TTL     time.Duration `yaml:"ttl" env:"CACHE_TTL" default:"5m"`
+	// MaxAge lets browsers reuse a response without asking; 0 makes them
+	// revalidate with If-Modified-Since every time.
+	MaxAge time.Duration `yaml:"max_age" env:"CACHE_MAX_AGE"`
+}
+
+type Log struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Format string `yaml:"format" env:"LOG_FORMAT" default:"json"`
+}
+
+type Auth struct {
+	// Secret signs the HS256 bearer tokens of the parent portal.
+	Secret string `yaml:"secret" env:"AUTH_SECRET" secret:"true"`
+	// Required rejects API calls without a bearer token or API key.
+	Required bool `yaml:"required" env:"AUTH_REQUIRED"`
+}
+
+// Tenant says how a request's school is found when its token names none.
+type Tenant struct {
+	// BaseDomain turns on subdomain routing: <slug>.<BaseDomain> is the
+	// school with that slug.
+	BaseDomain string `yaml:"base_domain" env:"TENANT_BASE_DOMAIN"`
+	// DefaultSchool serves requests naming no school; 0 rejects them.
+	DefaultSchool int `yaml:"default_school" env:"TENANT_DEFAULT_SCHOOL" default:"1"`
+}
+
+// minSecretLen keeps the HS256 key out of brute-force range.
+const minSecretLen = 16
+
+type Storage struct {
+	Backend        string   `yaml:"backend" env:"STORAGE_BACKEND" default:"local"`
+	Dir            string   `yaml:"dir" env:"STORAGE_DIR" default:"./uploads"`
+	S3             S3       `yaml:"s3"`
+	MaxUploadBytes int64    `yaml:"max_upload_bytes" env:"UPLOAD_MAX_BYTES" default:"10485760"`
+	AllowedTypes   []string `yaml:"allowed_types" env:"UPLOAD_ALLOWED_TYPES"` // empty: storage.DefaultLimits
+}
+
+type S3 struct {
+	Endpoint  string `yaml:"endpoint" env:"S3_ENDPOINT"`
+	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY" secret:"true"`
+	SecretKey string `yaml:"secret_key" env:"S3_SECRET_KEY" secret:"true"`
+	Bucket    string `yaml:"bucket" env:"S3_BUCKET"`
+	Region    string `yaml:"region" env:"S3_REGION"`
+	UseSSL    bool   `yaml:"use_ssl" env:"S3_USE_SSL" default:"true"`
+}
+
+// SMTP enables email notifications when Host is set.
+type SMTP struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     string `yaml:"port" env:"SMTP_PORT" default:"25"`
+	User     string `yaml:"user" env:"SMTP_USER"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+	From     string `yaml:"from" env:"SMTP_FROM" default:"journal@school.local"`
+}
+
+type Notify struct {
+	LowGradeThreshold int `yaml:"low_grade_threshold" env:"NOTIFY_LOW_GRADE_THRESHOLD" default:"2"`
+	DigestHour        int `yaml:"digest_hour" env:"NOTIFY_DIGEST_HOUR" default:"18"`
+}
+
+// Webhooks are delivered to public addresses only. AllowedNetworks are
+// CIDR ranges, such as a school's own network, that may be used as well.
+type Webhooks struct {
+	AllowedNetworks []string `yaml:"allowed_networks" env:"WEBHOOK_ALLOWED_NETWORKS"`
+}
+
+// Networks returns AllowedNetworks parsed; Validate has checked them.
+func (w Webhooks) Networks() []netip.Prefix {
+	var out []netip.Prefix
+	for _, n := range w.AllowedNetworks {
+		if p, err := netip.ParsePrefix(n); err == nil {
+			out = append(out, p.Masked())
+		}
+	}
+	return out
+}
+
+// Features switch optional parts of the server off.
+type Features struct {
+	GraphQL           bool `yaml:"graphql" env:"FEATURE_GRAPHQL" default:"true"`
+	GRPC              bool `yaml:"grpc" env:"FEATURE_GRPC" default:"true"`
+	Webhooks          bool `yaml:"webhooks" env:"FEATURE_WEBHOOKS" default:"true"`
+	LiveUpdates       bool `yaml:"live_updates" env:"FEATURE_LIVE_UPDATES" default:"true"`
+	ParentPortal      bool `yaml:"parent_portal" env:"FEATURE_PARENT_PORTAL" default:"true"`
+	Metrics           bool `yaml:"metrics" env:"FEATURE_METRICS" default:"true"`
+	RequestValidation bool `yaml:"request_validation" env:"FEATURE_REQUEST_VALIDATION" default:"true"`
+	RateLimit         bool `yaml:"rate_limit" env:"FEATURE_RATE_LIMIT" default:"true"`
+	Cache             bool `yaml:"cache" env:"FEATURE_CACHE" default:"true"`
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(validPort(c.HTTP.Port), "http.port (PORT): %q is not a port number", c.HTTP.Port)
+	check(validPort(c.GRPC.Port), "grpc.port (GRPC_PORT): %q is not a port number", c.GRPC.Port)
+	check(c.HTTP.ReadHeaderTimeout > 0, "http.read_header_timeout (HTTP_READ_HEADER_TIMEOUT) must be positive")
+	check(c.HTTP.ReadTimeout > 0, "http.read_timeout (HTTP_READ_TIMEOUT) must be positive")
+	check(c.HTTP.WriteTimeout > 0, "http.write_timeout (HTTP_WRITE_TIMEOUT) must be positive")
+	check(c.HTTP.IdleTimeout > 0, "http.idle_timeout (HTTP_IDLE_TIMEOUT) must be positive")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdown_timeout (SHUTDOWN_TIMEOUT) must be positive")
+	for _, p := range c.HTTP.TrustedProxies {
+		_, _, err := net.ParseCIDR(p)
+		check(err == nil || net.ParseIP(p) != nil, "http.trusted_proxies (TRUSTED_PROXIES): %q is not an IP address or CIDR range", p)
+	}
+
+	if c.DB.DSN == "" {
+		check(c.DB.Host != "", "db.host (DB_HOST) is required unless db.dsn (DB_DSN) is set")
+		check(c.DB.User != "", "db.user (DB_USER) is required unless db.dsn (DB_DSN) is set")
+		check(c.DB.Name != "", "db.name (DB_NAME) is required unless db.dsn (DB_DSN) is set")
+		check(validPort(c.DB.Port), "db.port (DB_PORT): %q is not a port number", c.DB.Port)
+	}
+	check(c.DB.MaxOpenConns >= 0, "db.max_open_conns (DB_MAX_OPEN_CONNS) must not be negative")
+	check(c.DB.MaxIdleConns >= 0, "db.max_idle_conns (DB_MAX_IDLE_CONNS) must not be negative")
+	check(c.DB.MaxOpenConns == 0 || c.DB.MaxIdleConns <= c.DB.MaxOpenConns,
+		"db.max_idle_conns (DB_MAX_IDLE_CONNS) must not exceed db.max_open_conns (DB_MAX_OPEN_CONNS)")
+
+	for _, o := range c.CORS.AllowedOrigins {
+		u, err := url.Parse(strings.Replace(o, "://*.", "://", 1))
+		check(o == "*" || (err == nil && u.Scheme != "" && u.Host != "" && u.User == nil && strings.TrimSuffix(u.Path, "/") == ""),
+			"cors.allowed_origins (CORS_ALLOWED_ORIGINS): %q must be *, scheme://host[:port] or scheme://*.host[:port]", o)
+		check(o != "*" || !c.CORS.AllowCredentials,
+			"cors.allowed_origins (CORS_ALLOWED_ORIGINS) must list origins, not *, when cors.allow_credentials is set")
+	}
+	check(c.CORS.MaxAge >= 0, "cors.max_age (CORS_MAX_AGE) must not be negative")
+
+	if c.Features.RateLimit {
+		check(oneOf(c.RateLimit.Backend, "memory", "postgres"), "rate_limit.backend (RATE_LIMIT_BACKEND): %q must be memory or postgres", c.RateLimit.Backend)
+		check(c.RateLimit.ReadPerMinute > 0, "rate_limit.read_per_minute (RATE_LIMIT_READ_PER_MINUTE) must be positive")
+		check(c.RateLimit.ReadBurst > 0, "rate_limit.read_burst (RATE_LIMIT_READ_BURST) must be positive")
+		check(c.RateLimit.WritePerMinute > 0, "rate_limit.write_per_minute (RATE_LIMIT_WRITE_PER_MINUTE) must be positive")
+		check(c.RateLimit.WriteBurst > 0, "rate_limit.write_burst (RATE_LIMIT_WRITE_BURST) must be positive")
+	}
+
+	if c.Features.Cache {
+		check(oneOf(c.Cache.Backend, "memory", "postgres"), "cache.backend (CACHE_BACKEND): %q must be memory or postgres", c.Cache.Backend)
+		check(c.Cache.TTL > 0, "cache.ttl (CACHE_TTL) must be positive")
+		check(c.Cache.MaxAge >= 0, "cache.max_age (CACHE_MAX_AGE) must not be negative")
+	}
+
+	check(oneOf(c.Log.Level, "debug", "info", "warn", "error"), "log.level (LOG_LEVEL): %q must be debug, info, warn or error", c.Log.Level)
+	check(oneOf(c.Log.Format, "json", "text"), "log.format (LOG_FORMAT): %q must be json or text", c.Log.Format)
+
+	if c.Features.ParentPortal {
+		check(len(c.Auth.Secret) >= minSecretLen,
+			"auth.secret (AUTH_SECRET) must be at least %d characters while the parent portal is enabled", minSecretLen)
+	}
+
+	check(c.Tenant.DefaultSchool >= 0, "tenant.default_school (TENANT_DEFAULT_SCHOOL) must not be negative")
+	check(c.Tenant.BaseDomain == "" || !strings.ContainsAny(c.Tenant.BaseDomain, "*/:"),
+		"tenant.base_domain (TENANT_BASE_DOMAIN): %q must be a domain name such as example.org", c.Tenant.BaseDomain)
+
+	switch c.Storage.Backend {
+	case "local":
+		check(c.Storage.Dir != "", "storage.dir (STORAGE_DIR) is required for the local backend")
+	case "s3":
+		check(c.Storage.S3.Endpoint != "", "storage.s3.endpoint (S3_ENDPOINT) is required for the s3 backend")
+		check(c.Storage.S3.Bucket != "", "storage.s3.bucket (S3_BUCKET) is required for the s3 backend")
+	default:
+		check(false, "storage.backend (STORAGE_BACKEND): %q must be local or s3", c.Storage.Backend)
+	}
+	check(c.Storage.MaxUploadBytes > 0, "storage.max_upload_bytes (UPLOAD_MAX_BYTES) must be positive")
+
+	if c.SMTP.Host != "" {
+		check(validPort(c.SMTP.Port), "smtp.port (SMTP_PORT): %q is not a port number", c.SMTP.Port)
+		check(c.SMTP.From != "", "smtp.from (SMTP_FROM) is required when smtp.host is set")
+	}
+	check(c.Notify.LowGradeThreshold >= 1, "notify.low_grade_threshold (NOTIFY_LOW_GRADE_THRESHOLD) must be at least 1")
+	check(c.Notify.DigestHour >= 0 && c.Notify.DigestHour <= 23, "notify.digest_hour (NOTIFY_DIGEST_HOUR) must be between 0 and 23")
+	for _, n := range c.Webhooks.AllowedNetworks {
+		_, err := netip.ParsePrefix(n)
+		check(err == nil, "webhooks.allowed_networks (WEBHOOK_ALLOWED_NETWORKS): %q is not a CIDR range", n)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validPort(p string) bool {
+	n, err := strconv.Atoi(p)
+	return err == nil && n >= 1 && n <= 65535
+}
+
+func oneOf(v string, allowed ...string) bool {
+	for _, a := range allowed {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
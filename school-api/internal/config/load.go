@@ -0,0 +1,144 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable holding the path of the YAML
+// file, used when Load is given no path.
+const FileEnv = "CONFIG_FILE"
+
+// Default returns the configuration made of the struct defaults alone.
+func Default() Config {
+	var c Config
+	if err := walk(&c, func(f field) error {
+		if d, ok := f.tag.Lookup("default"); ok {
+			return set(f.value, d)
+		}
+		return nil
+	}); err != nil {
+		panic("config: bad default: " + err.Error())
+	}
+	return c
+}
+
+// Load builds the configuration from the defaults, the YAML file at path
+// (or $CONFIG_FILE; none if both are empty), then the environment. A .env
+// file in the working directory is read first and fills in variables that
+// are not already set. Load does not validate; call Validate.
+func Load(path string) (Config, error) {
+	c := Default()
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return c, fmt.Errorf(".env: %w", err)
+	}
+	if path == "" {
+		path = os.Getenv(FileEnv)
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return c, err
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+			return c, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	var errs []error
+	walk(&c, func(f field) error {
+		name := f.tag.Get("env")
+		if v, ok := os.LookupEnv(name); ok && name != "" && v != "" {
+			if err := set(f.value, v); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+		return nil
+	})
+	return c, errors.Join(errs...)
+}
+
+// field is a leaf setting reached by walk.
+type field struct {
+	key   string // dotted YAML key, e.g. db.max_open_conns
+	tag   reflect.StructTag
+	value reflect.Value
+}
+
+// walk calls fn for every leaf field of the struct pointed to by v,
+// descending into nested sections.
+func walk(v any, fn func(field) error) error {
+	return walkValue(reflect.ValueOf(v).Elem(), "", fn)
+}
+
+func walkValue(v reflect.Value, prefix string, fn func(field) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := prefix + strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		fv := v.Field(i)
+		if sf.Type.Kind() == reflect.Struct && sf.Type != durationType {
+			if err := walkValue(fv, key+".", fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(field{key: key, tag: sf.Tag, value: fv}); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// set parses s into v according to its type. Lists are comma-separated.
+func set(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
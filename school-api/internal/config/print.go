@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces the value of a non-empty secret when printing.
+const redacted = "[redacted]"
+
+// Print writes c in the layout of the YAML file, with secrets redacted and
+// each setting annotated with its environment variable.
+func Print(w io.Writer, c Config) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	sections := map[string]*yaml.Node{"": root}
+	err := walk(&c, func(f field) error {
+		parent := section(sections, f.key)
+		name := f.key[strings.LastIndex(f.key, ".")+1:]
+		value := scalar(f.value)
+		if f.tag.Get("secret") == "true" && !f.value.IsZero() {
+			value = &yaml.Node{Kind: yaml.ScalarNode, Value: redacted}
+		}
+		if env := f.tag.Get("env"); env != "" {
+			value.LineComment = env
+		}
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	return enc.Close()
+}
+
+// section returns the mapping node that holds key, creating the enclosing
+// sections on first use.
+func section(sections map[string]*yaml.Node, key string) *yaml.Node {
+	i := strings.LastIndex(key, ".")
+	if i < 0 {
+		return sections[""]
+	}
+	path := key[:i]
+	if n, ok := sections[path]; ok {
+		return n
+	}
+	parent := section(sections, path)
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: path[strings.LastIndex(path, ".")+1:]}, n)
+	sections[path] = n
+	return n
+}
+
+func scalar(v reflect.Value) *yaml.Node {
+	if v.Type() == durationType {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: time.Duration(v.Int()).String()}
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for i := 0; i < v.Len(); i++ {
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: v.Index(i).String()})
+		}
+		return n
+	case reflect.Bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v.Bool())}
+	case reflect.Int, reflect.Int64:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(v.Int(), 10)}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.String()}
+	}
+}
@@ -0,0 +1,130 @@
+// Package cors answers browser cross-origin requests for an allowlist of
+// origins.
+package cors
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"school-api/internal/config"
+	"school-api/internal/logging"
+)
+
+const (
+	allowMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	allowHeaders = "Accept, Authorization, Content-Type, " + logging.RequestIDHeader
+)
+
+// exposeHeaders are response headers scripts on an allowed origin may read.
+var exposeHeaders = []string{
+	logging.RequestIDHeader,
+	"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After",
+}
+
+// policy is a parsed config.CORS.
+type policy struct {
+	any         bool     // "*" is listed
+	exact       []string // scheme://host[:port]
+	subdomains  []subdomains
+	credentials bool
+	maxAge      string
+}
+
+// subdomains matches scheme://*.host[:port].
+type subdomains struct {
+	scheme string // "https://"
+	suffix string // ".host[:port]"
+}
+
+// Middleware sets the CORS headers for requests from allowed origins and
+// answers preflight requests itself with 204. Responses always vary by
+// Origin unless every origin is allowed without credentials.
+//
+// With credentials the allowed origin is echoed back, since browsers
+// reject "*" together with Access-Control-Allow-Credentials.
+func Middleware(cfg config.CORS) gin.HandlerFunc {
+	p := parse(cfg)
+	wildcard := p.any && !p.credentials
+	expose := strings.Join(exposeHeaders, ", ")
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		if !wildcard {
+			h.Add("Vary", "Origin")
+		}
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if origin != "" && p.allows(origin) {
+			if wildcard {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+			if p.credentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+			if preflight {
+				h.Set("Access-Control-Allow-Methods", allowMethods)
+				h.Set("Access-Control-Allow-Headers", allowHeaders)
+				if p.maxAge != "" {
+					h.Set("Access-Control-Max-Age", p.maxAge)
+				}
+			} else {
+				h.Set("Access-Control-Expose-Headers", expose)
+			}
+		}
+		if preflight {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+func parse(cfg config.CORS) policy {
+	p := policy{credentials: cfg.AllowCredentials}
+	if cfg.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(int(cfg.MaxAge / time.Second))
+	}
+	for _, o := range cfg.AllowedOrigins {
+		o = strings.ToLower(strings.TrimSuffix(o, "/"))
+		scheme, host, ok := strings.Cut(o, "://")
+		switch {
+		case o == "*":
+			p.any = true
+		case ok && strings.HasPrefix(host, "*."):
+			p.subdomains = append(p.subdomains, subdomains{scheme: scheme + "://", suffix: host[1:]})
+		default:
+			p.exact = append(p.exact, o)
+		}
+	}
+	return p
+}
+
+// allows reports whether origin is on the list. A wildcard entry matches
+// subdomains at any depth but not the bare domain.
+func (p policy) allows(origin string) bool {
+	if p.any {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if u, err := url.Parse(origin); err != nil || u.Host == "" || u.User != nil || u.Path != "" {
+		return false
+	}
+	for _, o := range p.exact {
+		if origin == o {
+			return true
+		}
+	}
+	for _, w := range p.subdomains {
+		host, ok := strings.CutPrefix(origin, w.scheme)
+		if ok && len(host) > len(w.suffix) && strings.HasSuffix(host, w.suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,30 +1,43 @@
 package db
 
 import (
-    "fmt"
-    "os"
+    "database/sql"
 
+    "github.com/jackc/pgx/v5"
+    "github.com/jackc/pgx/v5/stdlib"
     "gorm.io/driver/postgres"
     "gorm.io/gorm"
+    "gorm.io/plugin/opentelemetry/tracing"
+    "school-api/internal/config"
+    "school-api/internal/logging"
+    "school-api/internal/tenant"
 )
 
-// Connect returns a gorm DB connection to Postgres using env vars.
-// Expected env variables:
-//   DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME, DB_SSLMODE
-func Connect() (*gorm.DB, error) {
-    host := os.Getenv("DB_HOST")
-    port := os.Getenv("DB_PORT")
-    user := os.Getenv("DB_USER")
-    pass := os.Getenv("DB_PASSWORD")
-    name := os.Getenv("DB_NAME")
-    ssl := os.Getenv("DB_SSLMODE")
-    if ssl == "" {
-        ssl = "disable"
+// Connect opens the Postgres connection pool described by cfg.
+// Statements are traced with OpenTelemetry as children of the span in the
+// statement's context; bound values are left out of the recorded SQL.
+// Statements whose context carries a school are confined to it (see
+// package tenant).
+func Connect(cfg config.DB) (*gorm.DB, error) {
+    pgCfg, err := pgx.ParseConfig(cfg.ConnString())
+    if err != nil {
+        return nil, err
     }
-    dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-        host, port, user, pass, name, ssl,
-    )
-    return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+    sqlDB := sql.OpenDB(tenant.Connector(stdlib.GetConnector(*pgCfg)))
+    db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logging.Gorm{}})
+    if err != nil {
+        sqlDB.Close()
+        return nil, err
+    }
+    sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+    sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+    sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+    sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+    if err := db.Use(tracing.NewPlugin(tracing.WithoutMetrics(), tracing.WithoutQueryVariables())); err != nil {
+        return nil, err
+    }
+    if err := db.Use(tenant.Plugin{}); err != nil {
+        return nil, err
+    }
+    return db, nil
 }
-
-
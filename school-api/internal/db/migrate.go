@@ -0,0 +1,321 @@
+package db
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "log/slog"
+    "sync"
+    "time"
+
+    "gorm.io/gorm"
+
+    "school-api/internal/models"
+    "school-api/internal/tenant"
+)
+
+// Migration is a versioned schema change that is applied once and
+// recorded in the schema_migrations table.
+type Migration struct {
+    ID string
+    Up func(tx *gorm.DB) error
+}
+
+// ErrPreconditionFailed is returned by a migration that cannot be applied
+// yet because of the current data (e.g. duplicates blocking a unique index).
+// Such migrations are left pending and retried on the next start.
+var ErrPreconditionFailed = errors.New("migration precondition failed")
+
+// blocked holds the migrations the last Migrate of this process left
+// pending with ErrPreconditionFailed, keyed by ID, with the reason.
+var blocked = struct {
+    sync.Mutex
+    m map[string]string
+}{m: map[string]string{}}
+
+type schemaMigration struct {
+    ID        string    `gorm:"primaryKey;type:varchar(100)"`
+    AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string { return "schema_migrations" }
+
+// autoMigrateModels are kept in sync with their struct definitions by
+// gorm AutoMigrate before versioned migrations run.
+var autoMigrateModels = []any{
+    &models.School{},
+    &models.Class{},
+    &models.Student{},
+    &models.Teacher{},
+    &models.Subject{},
+    &models.TeacherAssignment{},
+    &models.LessonSchedule{},
+    &models.LessonLog{},
+    &models.StudentLesson{},
+    &models.AttendanceStatus{},
+    &models.RolloverRun{},
+    &models.Enrollment{},
+    &models.Guardian{},
+    &models.Homework{},
+    &models.HomeworkAttachment{},
+    &models.HomeworkSubmission{},
+    &models.WebhookSubscription{},
+    &models.WebhookDelivery{},
+    &models.Notification{},
+    &models.RateLimitBucket{},
+    &models.APIKey{},
+    &models.CacheEntry{},
+}
+
+// migrations are applied in order. Never edit or reorder an entry that
+// has shipped; append a new one instead.
+var migrations = []Migration{
+    {ID: "0001_unique_class_grade_letter", Up: uniqueKeyMigration(classGradeLetterKey0001)},
+    {ID: "0002_unique_lesson_log_slot", Up: uniqueKeyMigration(LessonLogSlotKey)},
+    {ID: "0003_unique_teacher_assignment", Up: uniqueKeyMigration(TeacherAssignmentKey)},
+    {ID: "0004_unique_student_lesson", Up: uniqueKeyMigration(StudentLessonKey)},
+    {ID: "0005_unique_active_class_grade_letter", Up: func(tx *gorm.DB) error {
+        // Graduated classes keep their last grade/letter, so only active
+        // classes take part in the uniqueness rule.
+        if err := tx.Exec("DROP INDEX IF EXISTS " + classGradeLetterKey0001.Index).Error; err != nil {
+            return err
+        }
+        return uniqueKeyMigration(classGradeLetterKey0005)(tx)
+    }},
+    {ID: "0006_backfill_enrollments", Up: func(tx *gorm.DB) error {
+        // Students that existed before enrollment history get an open-ended
+        // enrollment in their current class with an unknown start date.
+        return tx.Exec(`INSERT INTO enrollments (student_id, class_id)
+            SELECT s.id, s.class_id FROM students s
+            WHERE NOT EXISTS (SELECT 1 FROM enrollments e WHERE e.student_id = s.id)`).Error
+    }},
+    {ID: "0007_search_indexes", Up: func(tx *gorm.DB) error {
+        // The tsvector expressions must match those in internal/search.
+        stmts := []string{
+            `CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+            `CREATE INDEX IF NOT EXISTS idx_students_fts ON students USING gin (to_tsvector('russian', coalesce(last_name, '') || ' ' || coalesce(first_name, '') || ' ' || coalesce(patronymic, '')))`,
+            `CREATE INDEX IF NOT EXISTS idx_teachers_fts ON teachers USING gin (to_tsvector('russian', coalesce(last_name, '') || ' ' || coalesce(first_name, '') || ' ' || coalesce(patronymic, '')))`,
+            `CREATE INDEX IF NOT EXISTS idx_subjects_fts ON subjects USING gin (to_tsvector('russian', coalesce(subject_name, '')))`,
+            `CREATE INDEX IF NOT EXISTS idx_students_last_name_trgm ON students USING gin (last_name gin_trgm_ops)`,
+            `CREATE INDEX IF NOT EXISTS idx_teachers_last_name_trgm ON teachers USING gin (last_name gin_trgm_ops)`,
+            `CREATE INDEX IF NOT EXISTS idx_subjects_name_trgm ON subjects USING gin (subject_name gin_trgm_ops)`,
+        }
+        for _, stmt := range stmts {
+            if err := tx.Exec(stmt).Error; err != nil {
+                return err
+            }
+        }
+        return nil
+    }},
+    {ID: "0008_schools", Up: func(tx *gorm.DB) error {
+        // Existing rows got school_id 1 from the column default when
+        // AutoMigrate added it: that school must exist.
+        stmts := []string{
+            `INSERT INTO schools (id, name, slug, created_at) VALUES (1, 'Default school', 'default', now()) ON CONFLICT DO NOTHING`,
+            `SELECT setval(pg_get_serial_sequence('schools', 'id'), (SELECT max(id) FROM schools))`,
+            // Natural keys are unique per school now
+            `DROP INDEX IF EXISTS uq_classes_grade_letter`,
+            `DROP INDEX IF EXISTS idx_rollover_runs_year`,
+        }
+        // Row-level security: with app.school_id set (see internal/tenant)
+        // only that school's rows are visible and writable; unset, as for
+        // migrations and background workers, all rows are.
+        const policy = `COALESCE(school_id = NULLIF(current_setting('app.school_id', true), '')::bigint, true)`
+        tables := []string{
+            "classes", "students", "teachers", "subjects", "teacher_assignments",
+            "lesson_schedules", "lesson_logs", "student_lessons", "rollover_runs",
+            "enrollments", "guardians", "homeworks", "homework_attachments",
+            "homework_submissions", "webhook_subscriptions", "webhook_deliveries",
+            "notifications", "api_keys",
+        }
+        for _, t := range tables {
+            stmts = append(stmts,
+                fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT fk_%s_school FOREIGN KEY (school_id) REFERENCES schools (id)`, t, t),
+                fmt.Sprintf(`ALTER TABLE %s ENABLE ROW LEVEL SECURITY`, t),
+                fmt.Sprintf(`ALTER TABLE %s FORCE ROW LEVEL SECURITY`, t),
+                fmt.Sprintf(`CREATE POLICY tenant_isolation ON %s USING (%s) WITH CHECK (%s)`, t, policy, policy),
+            )
+        }
+        for _, stmt := range stmts {
+            if err := tx.Exec(stmt).Error; err != nil {
+                return err
+            }
+        }
+        return uniqueKeyMigration(classGradeLetterKey0008)(tx)
+    }},
+    {ID: "0009_rls_fail_closed", Up: func(tx *gorm.DB) error {
+        // A connection without app.school_id must not see every school:
+        // the policies of 0008 now match no rows then. Code working
+        // across schools sets it to 'all' (tenant.AllSchools).
+        const policy = `CASE current_setting('app.school_id', true)
+            WHEN 'all' THEN true
+            ELSE school_id = NULLIF(current_setting('app.school_id', true), '')::bigint END`
+        var tables []string
+        if err := tx.Raw(`SELECT tablename FROM pg_policies WHERE schemaname = current_schema() AND policyname = 'tenant_isolation'`).
+            Scan(&tables).Error; err != nil {
+            return err
+        }
+        for _, t := range tables {
+            stmt := fmt.Sprintf(`ALTER POLICY tenant_isolation ON %s USING (%s) WITH CHECK (%s)`, t, policy, policy)
+            if err := tx.Exec(stmt).Error; err != nil {
+                return err
+            }
+        }
+        return nil
+    }},
+    {ID: "0010_guardian_students_rls", Up: func(tx *gorm.DB) error {
+        // The join table has no school_id: a link is visible and writable
+        // when both its guardian and its student are, which the policies
+        // of those tables decide.
+        const policy = `EXISTS (SELECT 1 FROM guardians g WHERE g.id = guardian_students.guardian_id)
+            AND EXISTS (SELECT 1 FROM students s WHERE s.id = guardian_students.student_id)`
+        stmts := []string{
+            `ALTER TABLE guardian_students ENABLE ROW LEVEL SECURITY`,
+            `ALTER TABLE guardian_students FORCE ROW LEVEL SECURITY`,
+            fmt.Sprintf(`CREATE POLICY tenant_isolation ON guardian_students USING (%s) WITH CHECK (%s)`, policy, policy),
+        }
+        for _, stmt := range stmts {
+            if err := tx.Exec(stmt).Error; err != nil {
+                return err
+            }
+        }
+        return nil
+    }},
+    {ID: "0011_school_foreign_keys", Up: func(tx *gorm.DB) error {
+        // Foreign key checks ignore row-level security, so a reference
+        // must name the school as well as the row: a row of one school
+        // can then never point at a row of another. The constraints are
+        // NOT VALID so that rows written before are not checked.
+        parents := []string{
+            "classes", "students", "teachers", "subjects", "lesson_logs",
+            "homeworks", "guardians", "webhook_subscriptions",
+        }
+        refs := []struct{ table, column, parent, onDelete string }{
+            {"students", "class_id", "classes", ""},
+            {"enrollments", "student_id", "students", "CASCADE"},
+            {"enrollments", "class_id", "classes", ""},
+            {"teacher_assignments", "teacher_id", "teachers", "CASCADE"},
+            {"teacher_assignments", "subject_id", "subjects", "CASCADE"},
+            {"lesson_schedules", "subject_id", "subjects", ""},
+            {"lesson_schedules", "class_id", "classes", ""},
+            {"lesson_schedules", "teacher_id", "teachers", ""},
+            {"lesson_logs", "subject_id", "subjects", ""},
+            {"lesson_logs", "class_id", "classes", ""},
+            {"lesson_logs", "teacher_id", "teachers", ""},
+            {"student_lessons", "student_id", "students", ""},
+            {"student_lessons", "lesson_id", "lesson_logs", ""},
+            {"homeworks", "lesson_id", "lesson_logs", ""},
+            {"homework_attachments", "homework_id", "homeworks", "CASCADE"},
+            {"homework_submissions", "homework_id", "homeworks", "CASCADE"},
+            {"homework_submissions", "student_id", "students", "CASCADE"},
+            {"notifications", "guardian_id", "guardians", "CASCADE"},
+            {"notifications", "student_id", "students", "CASCADE"},
+            {"webhook_deliveries", "subscription_id", "webhook_subscriptions", "CASCADE"},
+        }
+        var stmts []string
+        for _, t := range parents {
+            stmts = append(stmts, fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT uq_%s_school_id UNIQUE (school_id, id)`, t, t))
+        }
+        for _, r := range refs {
+            stmt := fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT fk_%s_%s_school FOREIGN KEY (school_id, %s) REFERENCES %s (school_id, id)`,
+                r.table, r.table, r.column, r.column, r.parent)
+            if r.onDelete != "" {
+                stmt += " ON DELETE " + r.onDelete
+            }
+            stmts = append(stmts, stmt+" NOT VALID")
+        }
+        for _, stmt := range stmts {
+            if err := tx.Exec(stmt).Error; err != nil {
+                return err
+            }
+        }
+        return nil
+    }},
+}
+
+// Migrate brings the schema up to date: AutoMigrate for the models, then
+// every versioned migration that has not been applied yet.
+func Migrate(db *gorm.DB) error {
+    // Migrations rewrite the rows of every school.
+    db = db.WithContext(tenant.AllSchools(context.Background()))
+    if err := db.AutoMigrate(autoMigrateModels...); err != nil {
+        return fmt.Errorf("auto migrate: %w", err)
+    }
+    if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+        return fmt.Errorf("schema_migrations: %w", err)
+    }
+    applied, err := appliedMigrations(db)
+    if err != nil {
+        return err
+    }
+    for _, m := range migrations {
+        if applied[m.ID] {
+            continue
+        }
+        err := db.Transaction(func(tx *gorm.DB) error {
+            if err := m.Up(tx); err != nil {
+                return err
+            }
+            return tx.Create(&schemaMigration{ID: m.ID, AppliedAt: time.Now()}).Error
+        })
+        if errors.Is(err, ErrPreconditionFailed) {
+            slog.Warn("Migration left pending", "migration", m.ID, "error", err)
+            setBlocked(m.ID, err.Error())
+            continue
+        }
+        if err != nil {
+            return fmt.Errorf("migration %s: %w", m.ID, err)
+        }
+        setBlocked(m.ID, "")
+        slog.Info("Applied migration", "migration", m.ID)
+    }
+    return nil
+}
+
+// PendingMigrations returns the IDs of migrations not yet applied.
+func PendingMigrations(db *gorm.DB) ([]string, error) {
+    applied, err := appliedMigrations(db)
+    if err != nil {
+        return nil, err
+    }
+    var pending []string
+    for _, m := range migrations {
+        if !applied[m.ID] {
+            pending = append(pending, m.ID)
+        }
+    }
+    return pending, nil
+}
+
+// BlockedMigration reports whether Migrate left the pending migration id
+// unapplied because its precondition failed, and why. Such a migration
+// waits for the data to be fixed (e.g. duplicates resolved) and is
+// retried on the next start.
+func BlockedMigration(id string) (reason string, ok bool) {
+    blocked.Lock()
+    defer blocked.Unlock()
+    reason, ok = blocked.m[id]
+    return reason, ok
+}
+
+func setBlocked(id, reason string) {
+    blocked.Lock()
+    defer blocked.Unlock()
+    if reason == "" {
+        delete(blocked.m, id)
+    } else {
+        blocked.m[id] = reason
+    }
+}
+
+func appliedMigrations(db *gorm.DB) (map[string]bool, error) {
+    var rows []schemaMigration
+    if err := db.Find(&rows).Error; err != nil {
+        return nil, fmt.Errorf("read schema_migrations: %w", err)
+    }
+    applied := make(map[string]bool, len(rows))
+    for _, r := range rows {
+        applied[r.ID] = true
+    }
+    return applied, nil
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+    "fmt"
+    "strconv"
+    "strings"
+
+    "gorm.io/gorm"
+    "school-api/internal/tenant"
+)
+
+// UniqueKey describes a natural key of a table that must be unique.
+type UniqueKey struct {
+    Resource string // API resource name, e.g. "classes"
+    Table    string
+    Index    string
+    Columns  []string
+    Where    string // optional predicate for a partial index
+}
+
+var (
+    // ClassGradeLetterKey is the current key of classes, the one
+    // migration 0008 created.
+    ClassGradeLetterKey = classGradeLetterKey0008
+    LessonLogSlotKey    = UniqueKey{
+        Resource: "lesson-logs",
+        Table:    "lesson_logs",
+        Index:    "uq_lesson_logs_class_date_number",
+        Columns:  []string{"class_id", "date", "number"},
+    }
+    TeacherAssignmentKey = UniqueKey{
+        Resource: "teacher-assignments",
+        Table:    "teacher_assignments",
+        Index:    "uq_teacher_assignments_teacher_subject",
+        Columns:  []string{"teacher_id", "subject_id"},
+    }
+    StudentLessonKey = UniqueKey{
+        Resource: "student-lessons",
+        Table:    "student_lessons",
+        Index:    "uq_student_lessons_student_lesson",
+        Columns:  []string{"student_id", "lesson_id"},
+    }
+)
+
+// Keys exactly as shipped migrations created them. A migration's key never
+// changes once it has shipped, or fresh installs would build a different
+// schema than upgraded ones; a new key gets a new variable.
+var (
+    classGradeLetterKey0001 = UniqueKey{
+        Resource: "classes",
+        Table:    "classes",
+        Index:    "uq_classes_grade_letter",
+        Columns:  []string{"grade", "letter"},
+    }
+    // 0005 replaced it with a partial index over active classes.
+    classGradeLetterKey0005 = UniqueKey{
+        Resource: "classes",
+        Table:    "classes",
+        Index:    "uq_classes_grade_letter",
+        Columns:  []string{"grade", "letter"},
+        Where:    "graduated_year IS NULL",
+    }
+    classGradeLetterKey0008 = UniqueKey{
+        Resource: "classes",
+        Table:    "classes",
+        Index:    "uq_classes_school_grade_letter",
+        Columns:  []string{"school_id", "grade", "letter"},
+        Where:    "graduated_year IS NULL",
+    }
+)
+
+// UniqueKeys lists every natural key enforced by migrations.
+var UniqueKeys = []UniqueKey{
+    ClassGradeLetterKey,
+    LessonLogSlotKey,
+    TeacherAssignmentKey,
+    StudentLessonKey,
+}
+
+// DuplicateGroup is a set of rows sharing the same natural key.
+type DuplicateGroup struct {
+    Resource string         `json:"resource"`
+    Key      map[string]any `json:"key"`
+    IDs      []uint         `json:"ids"`
+}
+
+// FindDuplicates returns every group of rows in the table that violates k,
+// within the school of db's context if it has one.
+func FindDuplicates(db *gorm.DB, k UniqueKey) ([]DuplicateGroup, error) {
+    cols := strings.Join(k.Columns, ", ")
+    var conds []string
+    var args []any
+    if k.Where != "" {
+        conds = append(conds, k.Where)
+    }
+    if id, ok := tenant.FromContext(db.Statement.Context); ok {
+        conds = append(conds, "school_id = ?")
+        args = append(args, id)
+    }
+    where := ""
+    if len(conds) > 0 {
+        where = " WHERE " + strings.Join(conds, " AND ")
+    }
+    query := fmt.Sprintf(
+        "SELECT %s, string_agg(id::text, ',' ORDER BY id) AS ids FROM %s%s GROUP BY %s HAVING count(*) > 1 ORDER BY %s",
+        cols, k.Table, where, cols, cols,
+    )
+    rows, err := db.Raw(query, args...).Rows()
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    var groups []DuplicateGroup
+    for rows.Next() {
+        values := make([]any, len(k.Columns)+1)
+        ptrs := make([]any, len(values))
+        for i := range values {
+            ptrs[i] = &values[i]
+        }
+        if err := rows.Scan(ptrs...); err != nil {
+            return nil, err
+        }
+        g := DuplicateGroup{Resource: k.Resource, Key: make(map[string]any, len(k.Columns))}
+        for i, col := range k.Columns {
+            g.Key[col] = values[i]
+        }
+        ids, _ := values[len(k.Columns)].(string)
+        for _, s := range strings.Split(ids, ",") {
+            id, err := strconv.ParseUint(s, 10, 64)
+            if err != nil {
+                return nil, err
+            }
+            g.IDs = append(g.IDs, uint(id))
+        }
+        groups = append(groups, g)
+    }
+    return groups, rows.Err()
+}
+
+// uniqueKeyMigration creates the unique index for k, unless duplicates
+// exist, in which case the migration stays pending until they are resolved
+// (see GET /api/v1/duplicates).
+func uniqueKeyMigration(k UniqueKey) func(tx *gorm.DB) error {
+    return func(tx *gorm.DB) error {
+        groups, err := FindDuplicates(tx, k)
+        if err != nil {
+            return err
+        }
+        if len(groups) > 0 {
+            return fmt.Errorf("%w: %d duplicate group(s) in %s on (%s)",
+                ErrPreconditionFailed, len(groups), k.Table, strings.Join(k.Columns, ", "))
+        }
+        stmt := fmt.Sprintf("CREATE UNIQUE INDEX IF NOT EXISTS %s ON %s (%s)",
+            k.Index, k.Table, strings.Join(k.Columns, ", "))
+        if k.Where != "" {
+            stmt += " WHERE " + k.Where
+        }
+        return tx.Exec(stmt).Error
+    }
+}
@@ -0,0 +1,136 @@
+// Package enrollment keeps the history of which class a student belonged
+// to and answers date-aware roster questions.
+package enrollment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"school-api/internal/models"
+)
+
+// DateLayout is the format of all enrollment dates.
+const DateLayout = "2006-01-02"
+
+var (
+	// ErrSameClass is returned when transferring a student to their current class.
+	ErrSameClass = errors.New("student is already in this class")
+	// ErrBeforeCurrent is returned when a transfer date precedes the start
+	// of the current enrollment.
+	ErrBeforeCurrent = errors.New("transfer date is before the current enrollment started")
+	// ErrClassNotFound is returned when the target class does not exist.
+	ErrClassNotFound = errors.New("class not found")
+	// ErrClassGraduated is returned when the target class has graduated
+	// and takes no students any more.
+	ErrClassGraduated = errors.New("class has graduated")
+)
+
+// Today returns the current date in DateLayout.
+func Today() string {
+	return time.Now().Format(DateLayout)
+}
+
+// ActiveOn restricts an enrollments query to periods covering date.
+func ActiveOn(date string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(enrollments.start_date IS NULL OR enrollments.start_date <= ?) AND (enrollments.end_date IS NULL OR enrollments.end_date > ?)", date, date)
+	}
+}
+
+// StudentsInClassOn restricts a students query to those enrolled in
+// classID on date.
+func StudentsInClassOn(classID uint, date string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		sub := db.Session(&gorm.Session{NewDB: true}).Model(&models.Enrollment{}).
+			Select("enrollments.student_id").
+			Where("enrollments.class_id = ?", classID).
+			Scopes(ActiveOn(date))
+		return db.Where("students.id IN (?)", sub)
+	}
+}
+
+// Open starts the first enrollment of a newly created student.
+func Open(tx *gorm.DB, student models.Student, date, reason string) error {
+	return tx.Create(&models.Enrollment{
+		StudentID: student.ID,
+		ClassID:   student.ClassID,
+		StartDate: &date,
+		Reason:    reason,
+	}).Error
+}
+
+// OpenClass loads classID for a student to join. It must be a class of
+// the school in tx's context that has not graduated; its row stays share
+// locked until tx ends, so a concurrent rollover cannot graduate it.
+func OpenClass(tx *gorm.DB, classID uint) (models.Class, error) {
+	var class models.Class
+	err := tx.Clauses(clause.Locking{Strength: "SHARE"}).First(&class, classID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return class, fmt.Errorf("%w (%d)", ErrClassNotFound, classID)
+	}
+	if err != nil {
+		return class, err
+	}
+	if class.GraduatedYear != nil {
+		return class, fmt.Errorf("%w (%d)", ErrClassGraduated, *class.GraduatedYear)
+	}
+	return class, nil
+}
+
+// Transfer closes the student's current enrollment on date and opens a new
+// one in classID starting the same day, updating Student.ClassID and
+// making the student active again. The target class is checked with
+// OpenClass.
+func Transfer(tx *gorm.DB, student *models.Student, classID uint, date, reason string) (models.Enrollment, error) {
+	if student.ClassID == classID {
+		return models.Enrollment{}, ErrSameClass
+	}
+	if _, err := OpenClass(tx, classID); err != nil {
+		return models.Enrollment{}, err
+	}
+	var current []models.Enrollment
+	if err := tx.Where("student_id = ? AND end_date IS NULL", student.ID).Find(&current).Error; err != nil {
+		return models.Enrollment{}, err
+	}
+	for _, e := range current {
+		if e.StartDate != nil && DateOnly(*e.StartDate) > date {
+			return models.Enrollment{}, fmt.Errorf("%w (%s)", ErrBeforeCurrent, *e.StartDate)
+		}
+	}
+	if err := tx.Model(&models.Enrollment{}).
+		Where("student_id = ? AND end_date IS NULL", student.ID).
+		Update("end_date", date).Error; err != nil {
+		return models.Enrollment{}, err
+	}
+	next := models.Enrollment{StudentID: student.ID, ClassID: classID, StartDate: &date, Reason: reason}
+	if err := tx.Create(&next).Error; err != nil {
+		return models.Enrollment{}, err
+	}
+	// An alumnus moved into a class that has not graduated is back at school.
+	student.ClassID = classID
+	student.Status = models.StudentActive
+	if err := tx.Model(student).Updates(map[string]any{"class_id": classID, "status": models.StudentActive}).Error; err != nil {
+		return models.Enrollment{}, err
+	}
+	return next, nil
+}
+
+// CloseClass ends every current enrollment in classID on date.
+func CloseClass(tx *gorm.DB, classID uint, date string) error {
+	return tx.Model(&models.Enrollment{}).
+		Where("class_id = ? AND end_date IS NULL", classID).
+		Update("end_date", date).Error
+}
+
+// DateOnly trims a scanned date column (which may carry a time part) to
+// DateLayout so it compares correctly with plain dates.
+func DateOnly(s string) string {
+	if len(s) > len(DateLayout) {
+		return s[:len(DateLayout)]
+	}
+	return s
+}
@@ -0,0 +1,102 @@
+// Package events is an in-process bus for domain events raised by the
+// journal handlers. Subscribers (webhooks, notifications, live streams)
+// react to them without the handlers knowing who listens.
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"school-api/internal/models"
+)
+
+// Event types.
+const (
+	StudentLessonCreated  = "student_lesson.created"
+	StudentLessonUpdated  = "student_lesson.updated"
+	StudentLessonDeleted  = "student_lesson.deleted"
+	LessonLogCreated      = "lesson_log.created"
+	LessonLogUpdated      = "lesson_log.updated"
+	LessonLogDeleted      = "lesson_log.deleted"
+	LessonScheduleCreated = "lesson_schedule.created"
+	LessonScheduleUpdated = "lesson_schedule.updated"
+	LessonScheduleDeleted = "lesson_schedule.deleted"
+
+	// GradeRecorded is raised when a student lesson gets a new or changed grade.
+	GradeRecorded = "grade.recorded"
+	// AbsenceRecorded is raised when a student lesson is marked absent.
+	AbsenceRecorded = "absence.recorded"
+)
+
+// Types lists every event type.
+var Types = []string{
+	StudentLessonCreated, StudentLessonUpdated, StudentLessonDeleted,
+	LessonLogCreated, LessonLogUpdated, LessonLogDeleted,
+	LessonScheduleCreated, LessonScheduleUpdated, LessonScheduleDeleted,
+	GradeRecorded, AbsenceRecorded,
+}
+
+// StudentLessonData is the payload of student lesson, grade and absence
+// events. ClassID lets subscribers route by class without a lookup; the
+// Previous* fields are set on updates.
+type StudentLessonData struct {
+	models.StudentLesson
+	ClassID                  uint   `json:"class_id"`
+	PreviousGrade            *int   `json:"previous_grade,omitempty"`
+	PreviousAttendanceStatus string `json:"previous_attendance_status,omitempty"`
+}
+
+// Event is something that happened in the journal.
+type Event struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+// New creates an event with a fresh ID.
+func New(typ string, data any) Event {
+	return Event{ID: newID(), Type: typ, OccurredAt: time.Now().UTC(), Data: data}
+}
+
+// Subscriber receives published events. It runs synchronously in the
+// publishing request, so it must be quick (enqueue, don't deliver).
+type Subscriber func(ctx context.Context, e Event)
+
+// Bus fans events out to subscribers. A nil *Bus discards events.
+type Bus struct {
+	mu   sync.RWMutex
+	subs []Subscriber
+}
+
+func NewBus() *Bus { return &Bus{} }
+
+func (b *Bus) Subscribe(s Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, s)
+}
+
+// Publish delivers each event to every subscriber.
+func (b *Bus) Publish(ctx context.Context, evs ...Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	subs := b.subs
+	b.mu.RUnlock()
+	for _, e := range evs {
+		for _, s := range subs {
+			s(ctx, e)
+		}
+	}
+}
+
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,157 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"gorm.io/gorm"
+
+	"school-api/internal/models"
+)
+
+// batchWait is how long a loader collects keys before querying. Sibling
+// fields of list items resolve concurrently, so a short window gathers a
+// whole level of the query into one IN (...) query.
+const batchWait = 2 * time.Millisecond
+
+// rangeKey identifies a parent's child list limited to a date range.
+type rangeKey struct {
+	ID       uint
+	From, To string
+}
+
+// loaders are created per request so caching never leaks between callers.
+type loaders struct {
+	class   *dataloader.Loader[uint, *models.Class]
+	student *dataloader.Loader[uint, *models.Student]
+	teacher *dataloader.Loader[uint, *models.Teacher]
+	subject *dataloader.Loader[uint, *models.Subject]
+	lesson  *dataloader.Loader[uint, *models.LessonLog]
+	status  *dataloader.Loader[string, *models.AttendanceStatus]
+
+	studentsByClass         *dataloader.Loader[uint, []models.Student]
+	scheduleByClass         *dataloader.Loader[uint, []models.LessonSchedule]
+	assignmentsByTeacher    *dataloader.Loader[uint, []models.TeacherAssignment]
+	assignmentsBySubject    *dataloader.Loader[uint, []models.TeacherAssignment]
+	studentLessonsByLesson  *dataloader.Loader[uint, []models.StudentLesson]
+	lessonLogsByClass       *dataloader.Loader[rangeKey, []models.LessonLog]
+	studentLessonsByStudent *dataloader.Loader[rangeKey, []models.StudentLesson]
+
+	// rows counts the list items returned so far, against maxRows.
+	rows atomic.Int64
+}
+
+func newLoaders(db *gorm.DB) *loaders {
+	return &loaders{
+		class:   dataloader.NewBatchedLoader(byKey(db, "id", func(m *models.Class) uint { return m.ID }), dataloader.WithWait[uint, *models.Class](batchWait)),
+		student: dataloader.NewBatchedLoader(byKey(db, "id", func(m *models.Student) uint { return m.ID }), dataloader.WithWait[uint, *models.Student](batchWait)),
+		teacher: dataloader.NewBatchedLoader(byKey(db, "id", func(m *models.Teacher) uint { return m.ID }), dataloader.WithWait[uint, *models.Teacher](batchWait)),
+		subject: dataloader.NewBatchedLoader(byKey(db, "id", func(m *models.Subject) uint { return m.ID }), dataloader.WithWait[uint, *models.Subject](batchWait)),
+		lesson:  dataloader.NewBatchedLoader(byKey(db, "id", func(m *models.LessonLog) uint { return m.ID }), dataloader.WithWait[uint, *models.LessonLog](batchWait)),
+		status:  dataloader.NewBatchedLoader(byKey(db, "code", func(m *models.AttendanceStatus) string { return m.Code }), dataloader.WithWait[string, *models.AttendanceStatus](batchWait)),
+
+		studentsByClass: dataloader.NewBatchedLoader(manyByKey(db, "class_id", "last_name, first_name",
+			func(m *models.Student) uint { return m.ClassID }), dataloader.WithWait[uint, []models.Student](batchWait)),
+		scheduleByClass: dataloader.NewBatchedLoader(manyByKey(db, "class_id", "weekday, number",
+			func(m *models.LessonSchedule) uint { return m.ClassID }), dataloader.WithWait[uint, []models.LessonSchedule](batchWait)),
+		assignmentsByTeacher: dataloader.NewBatchedLoader(manyByKey(db, "teacher_id", "id",
+			func(m *models.TeacherAssignment) uint { return m.TeacherID }), dataloader.WithWait[uint, []models.TeacherAssignment](batchWait)),
+		assignmentsBySubject: dataloader.NewBatchedLoader(manyByKey(db, "subject_id", "id",
+			func(m *models.TeacherAssignment) uint { return m.SubjectID }), dataloader.WithWait[uint, []models.TeacherAssignment](batchWait)),
+		studentLessonsByLesson: dataloader.NewBatchedLoader(manyByKey(db, "lesson_id", "id",
+			func(m *models.StudentLesson) uint { return m.LessonID }), dataloader.WithWait[uint, []models.StudentLesson](batchWait)),
+
+		lessonLogsByClass: dataloader.NewBatchedLoader(manyInRange(db,
+			func(tx *gorm.DB) *gorm.DB { return tx.Table("lesson_logs") },
+			"lesson_logs.class_id", "lesson_logs.date", "lesson_logs.date, lesson_logs.number",
+			func(m *models.LessonLog) uint { return m.ClassID }), dataloader.WithWait[rangeKey, []models.LessonLog](batchWait)),
+		studentLessonsByStudent: dataloader.NewBatchedLoader(manyInRange(db,
+			func(tx *gorm.DB) *gorm.DB {
+				return tx.Table("student_lessons").Select("student_lessons.*").
+					Joins("JOIN lesson_logs ON lesson_logs.id = student_lessons.lesson_id")
+			},
+			"student_lessons.student_id", "lesson_logs.date", "lesson_logs.date, lesson_logs.number",
+			func(m *models.StudentLesson) uint { return m.StudentID }), dataloader.WithWait[rangeKey, []models.StudentLesson](batchWait)),
+	}
+}
+
+// spend counts n list items against the request's row budget.
+func (l *loaders) spend(n int) error {
+	if l.rows.Add(int64(n)) > maxRows {
+		return ArgumentError(fmt.Sprintf("query returns more than %d rows; narrow it with first, from or to", maxRows))
+	}
+	return nil
+}
+
+// byKey loads single rows whose column is one of the keys.
+func byKey[K comparable, M any](db *gorm.DB, column string, keyOf func(*M) K) dataloader.BatchFunc[K, *M] {
+	return func(ctx context.Context, keys []K) []*dataloader.Result[*M] {
+		var rows []M
+		err := db.WithContext(ctx).Where(column+" IN ?", keys).Find(&rows).Error
+		index := make(map[K]*M, len(rows))
+		for i := range rows {
+			index[keyOf(&rows[i])] = &rows[i]
+		}
+		out := make([]*dataloader.Result[*M], len(keys))
+		for i, k := range keys {
+			out[i] = &dataloader.Result[*M]{Data: index[k], Error: err}
+		}
+		return out
+	}
+}
+
+// manyByKey loads the child rows of every parent key in one query.
+func manyByKey[K comparable, M any](db *gorm.DB, column, order string, keyOf func(*M) K) dataloader.BatchFunc[K, []M] {
+	return func(ctx context.Context, keys []K) []*dataloader.Result[[]M] {
+		var rows []M
+		err := db.WithContext(ctx).Where(column+" IN ?", keys).Order(order).Find(&rows).Error
+		return group(keys, rows, err, keyOf)
+	}
+}
+
+// manyInRange is manyByKey for children filtered by a date range. Keys
+// sharing a range are loaded together, one query per distinct range.
+func manyInRange[M any](db *gorm.DB, base func(*gorm.DB) *gorm.DB, column, dateColumn, order string, keyOf func(*M) uint) dataloader.BatchFunc[rangeKey, []M] {
+	return func(ctx context.Context, keys []rangeKey) []*dataloader.Result[[]M] {
+		type span struct{ from, to string }
+		ids := map[span][]uint{}
+		at := map[span][]int{}
+		for i, k := range keys {
+			s := span{k.From, k.To}
+			ids[s] = append(ids[s], k.ID)
+			at[s] = append(at[s], i)
+		}
+		out := make([]*dataloader.Result[[]M], len(keys))
+		for s, parents := range ids {
+			q := base(db.WithContext(ctx)).Where(column+" IN ?", parents).Order(order)
+			if s.from != "" {
+				q = q.Where(dateColumn+" >= ?", s.from)
+			}
+			if s.to != "" {
+				q = q.Where(dateColumn+" <= ?", s.to)
+			}
+			var rows []M
+			err := q.Find(&rows).Error
+			for j, result := range group(parents, rows, err, keyOf) {
+				out[at[s][j]] = result
+			}
+		}
+		return out
+	}
+}
+
+func group[K comparable, M any](keys []K, rows []M, err error, keyOf func(*M) K) []*dataloader.Result[[]M] {
+	index := make(map[K][]M, len(keys))
+	for i := range rows {
+		k := keyOf(&rows[i])
+		index[k] = append(index[k], rows[i])
+	}
+	out := make([]*dataloader.Result[[]M], len(keys))
+	for i, k := range keys {
+		out[i] = &dataloader.Result[[]M]{Data: index[k], Error: err}
+	}
+	return out
+}
@@ -0,0 +1,250 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"gorm.io/gorm"
+
+	"school-api/internal/models"
+)
+
+// Resolver is the root Query resolver.
+type Resolver struct {
+	db *gorm.DB
+}
+
+// pageArgs are the arguments of list fields without filters. graphql-go
+// does not unpack embedded structs, so filtered lists repeat the fields.
+type pageArgs struct {
+	First  int32
+	Offset int32
+}
+
+func (r *Resolver) Classes(ctx context.Context, args struct {
+	Grade            *int32
+	IncludeGraduated bool
+	First            int32
+	Offset           int32
+}) ([]*classResolver, error) {
+	q := r.db.WithContext(ctx).Order("grade, letter")
+	if args.Grade != nil {
+		q = q.Where("grade = ?", *args.Grade)
+	}
+	if !args.IncludeGraduated {
+		q = q.Where("graduated_year IS NULL")
+	}
+	var items []models.Class
+	if err := page(q, args.First, args.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newClass)
+}
+
+func (r *Resolver) Class(ctx context.Context, args struct{ ID int32 }) (*classResolver, error) {
+	return load(ctx, loadersFrom(ctx).class, uint(args.ID), newClass)
+}
+
+func (r *Resolver) Students(ctx context.Context, args struct {
+	ClassID *int32
+	First   int32
+	Offset  int32
+}) ([]*studentResolver, error) {
+	q := r.db.WithContext(ctx).Order("last_name, first_name, id")
+	if args.ClassID != nil {
+		q = q.Where("class_id = ?", *args.ClassID)
+	}
+	var items []models.Student
+	if err := page(q, args.First, args.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newStudent)
+}
+
+func (r *Resolver) Student(ctx context.Context, args struct{ ID int32 }) (*studentResolver, error) {
+	return load(ctx, loadersFrom(ctx).student, uint(args.ID), newStudent)
+}
+
+func (r *Resolver) Teachers(ctx context.Context, args pageArgs) ([]*teacherResolver, error) {
+	var items []models.Teacher
+	q := r.db.WithContext(ctx).Order("last_name, first_name, id")
+	if err := page(q, args.First, args.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newTeacher)
+}
+
+func (r *Resolver) Teacher(ctx context.Context, args struct{ ID int32 }) (*teacherResolver, error) {
+	return load(ctx, loadersFrom(ctx).teacher, uint(args.ID), newTeacher)
+}
+
+func (r *Resolver) Subjects(ctx context.Context, args pageArgs) ([]*subjectResolver, error) {
+	var items []models.Subject
+	q := r.db.WithContext(ctx).Order("subject_name, id")
+	if err := page(q, args.First, args.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newSubject)
+}
+
+func (r *Resolver) Subject(ctx context.Context, args struct{ ID int32 }) (*subjectResolver, error) {
+	return load(ctx, loadersFrom(ctx).subject, uint(args.ID), newSubject)
+}
+
+func (r *Resolver) TeacherAssignments(ctx context.Context, args struct {
+	TeacherID *int32
+	SubjectID *int32
+	First     int32
+	Offset    int32
+}) ([]*assignmentResolver, error) {
+	q := r.db.WithContext(ctx).Order("id")
+	if args.TeacherID != nil {
+		q = q.Where("teacher_id = ?", *args.TeacherID)
+	}
+	if args.SubjectID != nil {
+		q = q.Where("subject_id = ?", *args.SubjectID)
+	}
+	var items []models.TeacherAssignment
+	if err := page(q, args.First, args.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newAssignment)
+}
+
+func (r *Resolver) LessonSchedules(ctx context.Context, args struct {
+	ClassID   *int32
+	TeacherID *int32
+	Weekday   *int32
+	First     int32
+	Offset    int32
+}) ([]*scheduleResolver, error) {
+	q := r.db.WithContext(ctx).Order("weekday, number, id")
+	if args.ClassID != nil {
+		q = q.Where("class_id = ?", *args.ClassID)
+	}
+	if args.TeacherID != nil {
+		q = q.Where("teacher_id = ?", *args.TeacherID)
+	}
+	if args.Weekday != nil {
+		q = q.Where("weekday = ?", *args.Weekday)
+	}
+	var items []models.LessonSchedule
+	if err := page(q, args.First, args.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newSchedule)
+}
+
+func (r *Resolver) LessonLogs(ctx context.Context, args struct {
+	ClassID   *int32
+	TeacherID *int32
+	SubjectID *int32
+	From      *string
+	To        *string
+	First     int32
+	Offset    int32
+}) ([]*lessonLogResolver, error) {
+	from, err := dateArg("from", args.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := dateArg("to", args.To)
+	if err != nil {
+		return nil, err
+	}
+	q := r.db.WithContext(ctx).Order("date, number, id")
+	if args.ClassID != nil {
+		q = q.Where("class_id = ?", *args.ClassID)
+	}
+	if args.TeacherID != nil {
+		q = q.Where("teacher_id = ?", *args.TeacherID)
+	}
+	if args.SubjectID != nil {
+		q = q.Where("subject_id = ?", *args.SubjectID)
+	}
+	if from != "" {
+		q = q.Where("date >= ?", from)
+	}
+	if to != "" {
+		q = q.Where("date <= ?", to)
+	}
+	var items []models.LessonLog
+	if err := page(q, args.First, args.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newLessonLog)
+}
+
+func (r *Resolver) LessonLog(ctx context.Context, args struct{ ID int32 }) (*lessonLogResolver, error) {
+	return load(ctx, loadersFrom(ctx).lesson, uint(args.ID), newLessonLog)
+}
+
+func (r *Resolver) StudentLessons(ctx context.Context, args struct {
+	StudentID *int32
+	LessonID  *int32
+	First     int32
+	Offset    int32
+}) ([]*studentLessonResolver, error) {
+	q := r.db.WithContext(ctx).Order("id")
+	if args.StudentID != nil {
+		q = q.Where("student_id = ?", *args.StudentID)
+	}
+	if args.LessonID != nil {
+		q = q.Where("lesson_id = ?", *args.LessonID)
+	}
+	var items []models.StudentLesson
+	if err := page(q, args.First, args.Offset).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newStudentLesson)
+}
+
+func (r *Resolver) StudentLesson(ctx context.Context, args struct{ ID int32 }) (*studentLessonResolver, error) {
+	var item models.StudentLesson
+	err := r.db.WithContext(ctx).First(&item, args.ID).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newStudentLesson(&item), nil
+}
+
+func (r *Resolver) AttendanceStatuses(ctx context.Context) ([]*statusResolver, error) {
+	var items []models.AttendanceStatus
+	if err := r.db.WithContext(ctx).Order("code").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return list(ctx, items, nil, newStatus)
+}
+
+// list turns the rows of a list field into resolvers once they fit the
+// request's row budget.
+func list[M any, R any](ctx context.Context, items []M, err error, fn func(*M) R) ([]R, error) {
+	if err != nil {
+		return nil, err
+	}
+	if err := loadersFrom(ctx).spend(len(items)); err != nil {
+		return nil, err
+	}
+	return wrap(items, fn), nil
+}
+
+// wrap turns loaded rows into resolvers.
+func wrap[M any, R any](items []M, fn func(*M) R) []R {
+	out := make([]R, len(items))
+	for i := range items {
+		out[i] = fn(&items[i])
+	}
+	return out
+}
+
+// load fetches one row through a loader; a missing row resolves to null.
+func load[K comparable, M any, R any](ctx context.Context, l *dataloader.Loader[K, *M], key K, fn func(*M) *R) (*R, error) {
+	item, err := l.Load(ctx, key)()
+	if err != nil || item == nil {
+		return nil, err
+	}
+	return fn(item), nil
+}
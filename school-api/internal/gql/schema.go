@@ -0,0 +1,88 @@
+// Package gql serves the journal over GraphQL. It exposes the same models
+// as the REST API and batches relationship lookups with per-request
+// dataloaders, so a nested query costs one SQL query per level rather than
+// one per row.
+package gql
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"gorm.io/gorm"
+
+	"school-api/internal/enrollment"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxFirst caps page size so a single query cannot dump whole tables.
+const maxFirst = 1000
+
+// maxDepth caps how deeply fields may nest, and maxRows caps the list
+// items one request returns across all levels: nested lists have no
+// first argument, so without them each level multiplies the last.
+const (
+	maxDepth = 8
+	maxRows  = 10000
+)
+
+// NewSchema parses the schema and binds it to db.
+func NewSchema(db *gorm.DB) *graphql.Schema {
+	return graphql.MustParseSchema(schemaSDL, &Resolver{db: db}, graphql.MaxDepth(maxDepth))
+}
+
+type ctxKey struct{}
+
+// WithLoaders returns a context carrying fresh dataloaders. Call it once
+// per request before executing a query.
+func WithLoaders(ctx context.Context, db *gorm.DB) context.Context {
+	return context.WithValue(ctx, ctxKey{}, newLoaders(db))
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(ctxKey{}).(*loaders)
+}
+
+func page(q *gorm.DB, first, offset int32) *gorm.DB {
+	n := int(first)
+	if n < 0 {
+		n = 0
+	}
+	if n > maxFirst {
+		n = maxFirst
+	}
+	q = q.Limit(n)
+	if offset > 0 {
+		q = q.Offset(int(offset))
+	}
+	return q
+}
+
+// ArgumentError is a resolver error caused by the query itself. Its message
+// is meant for the client; other resolver errors are internal.
+type ArgumentError string
+
+func (e ArgumentError) Error() string { return string(e) }
+
+// dateArg validates an optional YYYY-MM-DD argument.
+func dateArg(name string, v *string) (string, error) {
+	if v == nil || *v == "" {
+		return "", nil
+	}
+	if _, err := time.Parse(enrollment.DateLayout, *v); err != nil {
+		return "", ArgumentError(fmt.Sprintf("%s must be a date in YYYY-MM-DD format", name))
+	}
+	return *v, nil
+}
+
+func ptrInt32(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
@@ -0,0 +1,196 @@
+package gql
+
+import (
+	"context"
+
+	"school-api/internal/enrollment"
+	"school-api/internal/models"
+)
+
+type classResolver struct{ m *models.Class }
+
+func newClass(m *models.Class) *classResolver { return &classResolver{m} }
+
+func (r *classResolver) ID() int32             { return int32(r.m.ID) }
+func (r *classResolver) Grade() int32          { return int32(r.m.Grade) }
+func (r *classResolver) Letter() string        { return r.m.Letter }
+func (r *classResolver) GraduatedYear() *int32 { return ptrInt32(r.m.GraduatedYear) }
+
+func (r *classResolver) Students(ctx context.Context) ([]*studentResolver, error) {
+	items, err := loadersFrom(ctx).studentsByClass.Load(ctx, r.m.ID)()
+	return list(ctx, items, err, newStudent)
+}
+
+func (r *classResolver) Schedule(ctx context.Context) ([]*scheduleResolver, error) {
+	items, err := loadersFrom(ctx).scheduleByClass.Load(ctx, r.m.ID)()
+	return list(ctx, items, err, newSchedule)
+}
+
+func (r *classResolver) LessonLogs(ctx context.Context, args struct{ From, To *string }) ([]*lessonLogResolver, error) {
+	key, err := newRangeKey(r.m.ID, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+	items, err := loadersFrom(ctx).lessonLogsByClass.Load(ctx, key)()
+	return list(ctx, items, err, newLessonLog)
+}
+
+type studentResolver struct{ m *models.Student }
+
+func newStudent(m *models.Student) *studentResolver { return &studentResolver{m} }
+
+func (r *studentResolver) ID() int32          { return int32(r.m.ID) }
+func (r *studentResolver) ClassID() int32     { return int32(r.m.ClassID) }
+func (r *studentResolver) FirstName() string  { return r.m.FirstName }
+func (r *studentResolver) LastName() string   { return r.m.LastName }
+func (r *studentResolver) Patronymic() string { return r.m.Patronymic }
+func (r *studentResolver) Status() string     { return r.m.Status }
+
+func (r *studentResolver) Class(ctx context.Context) (*classResolver, error) {
+	return load(ctx, loadersFrom(ctx).class, r.m.ClassID, newClass)
+}
+
+func (r *studentResolver) Lessons(ctx context.Context, args struct{ From, To *string }) ([]*studentLessonResolver, error) {
+	key, err := newRangeKey(r.m.ID, args.From, args.To)
+	if err != nil {
+		return nil, err
+	}
+	items, err := loadersFrom(ctx).studentLessonsByStudent.Load(ctx, key)()
+	return list(ctx, items, err, newStudentLesson)
+}
+
+type teacherResolver struct{ m *models.Teacher }
+
+func newTeacher(m *models.Teacher) *teacherResolver { return &teacherResolver{m} }
+
+func (r *teacherResolver) ID() int32          { return int32(r.m.ID) }
+func (r *teacherResolver) FirstName() string  { return r.m.FirstName }
+func (r *teacherResolver) LastName() string   { return r.m.LastName }
+func (r *teacherResolver) Patronymic() string { return r.m.Patronymic }
+
+func (r *teacherResolver) Assignments(ctx context.Context) ([]*assignmentResolver, error) {
+	items, err := loadersFrom(ctx).assignmentsByTeacher.Load(ctx, r.m.ID)()
+	return list(ctx, items, err, newAssignment)
+}
+
+type subjectResolver struct{ m *models.Subject }
+
+func newSubject(m *models.Subject) *subjectResolver { return &subjectResolver{m} }
+
+func (r *subjectResolver) ID() int32           { return int32(r.m.ID) }
+func (r *subjectResolver) SubjectName() string { return r.m.SubjectName }
+
+func (r *subjectResolver) Assignments(ctx context.Context) ([]*assignmentResolver, error) {
+	items, err := loadersFrom(ctx).assignmentsBySubject.Load(ctx, r.m.ID)()
+	return list(ctx, items, err, newAssignment)
+}
+
+type assignmentResolver struct{ m *models.TeacherAssignment }
+
+func newAssignment(m *models.TeacherAssignment) *assignmentResolver { return &assignmentResolver{m} }
+
+func (r *assignmentResolver) ID() int32        { return int32(r.m.ID) }
+func (r *assignmentResolver) TeacherID() int32 { return int32(r.m.TeacherID) }
+func (r *assignmentResolver) SubjectID() int32 { return int32(r.m.SubjectID) }
+
+func (r *assignmentResolver) Teacher(ctx context.Context) (*teacherResolver, error) {
+	return load(ctx, loadersFrom(ctx).teacher, r.m.TeacherID, newTeacher)
+}
+
+func (r *assignmentResolver) Subject(ctx context.Context) (*subjectResolver, error) {
+	return load(ctx, loadersFrom(ctx).subject, r.m.SubjectID, newSubject)
+}
+
+type scheduleResolver struct{ m *models.LessonSchedule }
+
+func newSchedule(m *models.LessonSchedule) *scheduleResolver { return &scheduleResolver{m} }
+
+func (r *scheduleResolver) ID() int32        { return int32(r.m.ID) }
+func (r *scheduleResolver) SubjectID() int32 { return int32(r.m.SubjectID) }
+func (r *scheduleResolver) Weekday() int32   { return int32(r.m.Weekday) }
+func (r *scheduleResolver) Number() int32    { return int32(r.m.Number) }
+func (r *scheduleResolver) ClassID() int32   { return int32(r.m.ClassID) }
+func (r *scheduleResolver) TeacherID() int32 { return int32(r.m.TeacherID) }
+
+func (r *scheduleResolver) Subject(ctx context.Context) (*subjectResolver, error) {
+	return load(ctx, loadersFrom(ctx).subject, r.m.SubjectID, newSubject)
+}
+
+func (r *scheduleResolver) Class(ctx context.Context) (*classResolver, error) {
+	return load(ctx, loadersFrom(ctx).class, r.m.ClassID, newClass)
+}
+
+func (r *scheduleResolver) Teacher(ctx context.Context) (*teacherResolver, error) {
+	return load(ctx, loadersFrom(ctx).teacher, r.m.TeacherID, newTeacher)
+}
+
+type lessonLogResolver struct{ m *models.LessonLog }
+
+func newLessonLog(m *models.LessonLog) *lessonLogResolver { return &lessonLogResolver{m} }
+
+func (r *lessonLogResolver) ID() int32        { return int32(r.m.ID) }
+func (r *lessonLogResolver) SubjectID() int32 { return int32(r.m.SubjectID) }
+func (r *lessonLogResolver) Date() string     { return enrollment.DateOnly(r.m.Date) }
+func (r *lessonLogResolver) Number() int32    { return int32(r.m.Number) }
+func (r *lessonLogResolver) ClassID() int32   { return int32(r.m.ClassID) }
+func (r *lessonLogResolver) TeacherID() int32 { return int32(r.m.TeacherID) }
+
+func (r *lessonLogResolver) Subject(ctx context.Context) (*subjectResolver, error) {
+	return load(ctx, loadersFrom(ctx).subject, r.m.SubjectID, newSubject)
+}
+
+func (r *lessonLogResolver) Class(ctx context.Context) (*classResolver, error) {
+	return load(ctx, loadersFrom(ctx).class, r.m.ClassID, newClass)
+}
+
+func (r *lessonLogResolver) Teacher(ctx context.Context) (*teacherResolver, error) {
+	return load(ctx, loadersFrom(ctx).teacher, r.m.TeacherID, newTeacher)
+}
+
+func (r *lessonLogResolver) StudentLessons(ctx context.Context) ([]*studentLessonResolver, error) {
+	items, err := loadersFrom(ctx).studentLessonsByLesson.Load(ctx, r.m.ID)()
+	return list(ctx, items, err, newStudentLesson)
+}
+
+type studentLessonResolver struct{ m *models.StudentLesson }
+
+func newStudentLesson(m *models.StudentLesson) *studentLessonResolver {
+	return &studentLessonResolver{m}
+}
+
+func (r *studentLessonResolver) ID() int32                { return int32(r.m.ID) }
+func (r *studentLessonResolver) StudentID() int32         { return int32(r.m.StudentID) }
+func (r *studentLessonResolver) LessonID() int32          { return int32(r.m.LessonID) }
+func (r *studentLessonResolver) Grade() *int32            { return ptrInt32(r.m.Grade) }
+func (r *studentLessonResolver) AttendanceStatus() string { return r.m.AttendanceStatus }
+
+func (r *studentLessonResolver) Student(ctx context.Context) (*studentResolver, error) {
+	return load(ctx, loadersFrom(ctx).student, r.m.StudentID, newStudent)
+}
+
+func (r *studentLessonResolver) Lesson(ctx context.Context) (*lessonLogResolver, error) {
+	return load(ctx, loadersFrom(ctx).lesson, r.m.LessonID, newLessonLog)
+}
+
+func (r *studentLessonResolver) Status(ctx context.Context) (*statusResolver, error) {
+	return load(ctx, loadersFrom(ctx).status, r.m.AttendanceStatus, newStatus)
+}
+
+type statusResolver struct{ m *models.AttendanceStatus }
+
+func newStatus(m *models.AttendanceStatus) *statusResolver { return &statusResolver{m} }
+
+func (r *statusResolver) Code() string        { return r.m.Code }
+func (r *statusResolver) Description() string { return r.m.Description }
+
+func newRangeKey(id uint, from, to *string) (rangeKey, error) {
+	f, err := dateArg("from", from)
+	if err != nil {
+		return rangeKey{}, err
+	}
+	t, err := dateArg("to", to)
+	if err != nil {
+		return rangeKey{}, err
+	}
+	return rangeKey{ID: id, From: f, To: t}, nil
+}
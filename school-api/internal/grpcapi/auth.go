@@ -0,0 +1,159 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"school-api/internal/apikey"
+	"school-api/internal/auth"
+	"school-api/internal/ratelimit"
+)
+
+// Auth returns the server options that guard every call like the REST
+// middleware guards /api/v1. The "authorization" metadata takes the same
+// "Bearer <token>" or "ApiKey <key>" as the REST header. Invalid
+// credentials fail with Unauthenticated, and so do missing ones when
+// required is set. Guardians are refused, since the parent portal is
+// REST only, and API keys need the scope of the method. limiter, which
+// may be nil, takes a token from the caller's read or write bucket.
+func Auth(authn auth.Authenticator, required bool, limiter *ratelimit.Limiter) []grpc.ServerOption {
+	g := guard{authn: authn, required: required, limiter: limiter}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, err := g.check(ctx, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := g.check(ss.Context(), info.FullMethod)
+			if err != nil {
+				return err
+			}
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
+
+type guard struct {
+	authn    auth.Authenticator
+	required bool
+	limiter  *ratelimit.Limiter
+}
+
+// check authenticates and rate-limits a call to method and returns ctx
+// carrying the caller's principal, if any.
+func (g guard) check(ctx context.Context, method string) (context.Context, error) {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			authorization = v[0]
+		}
+	}
+	p, ok, err := g.authn.Credentials(ctx, authorization)
+	switch {
+	case err != nil:
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	case !ok && g.required:
+		return nil, status.Error(codes.Unauthenticated, "bearer token or API key required")
+	}
+	write := writes(method)
+	if write && strings.HasPrefix(method, sharedService) {
+		return nil, status.Error(codes.PermissionDenied, "attendance statuses are shared by all schools and changed by operators over REST")
+	}
+	if ok {
+		if !slices.Contains(auth.StaffRoles, p.Role) {
+			return nil, status.Error(codes.PermissionDenied, "requires role: "+strings.Join(auth.StaffRoles, ", "))
+		}
+		if err := scope(p, method, write); err != nil {
+			return nil, err
+		}
+		ctx = auth.WithPrincipal(ctx, p)
+	}
+	if g.limiter != nil {
+		if err := g.limit(ctx, ratelimit.ClientKey(p, ok, peerIP(ctx)), write); err != nil {
+			return nil, err
+		}
+	}
+	return ctx, nil
+}
+
+// sharedService holds data shared by all schools. Only an operator may
+// change it, which gRPC, always bound to one school, does not serve.
+const sharedService = "/journal.v1.AttendanceStatusService/"
+
+// scope refuses an API key principal that lacks the scope of method, as
+// auth.RequireScope does for REST routes. Server reflection only
+// describes the API and is open to every key.
+func scope(p auth.Principal, method string, write bool) error {
+	if p.Role != auth.RoleService || strings.HasPrefix(method, "/grpc.reflection.") {
+		return nil
+	}
+	need := apikey.ScopeForMethod(method, write)
+	if need == "" {
+		return status.Error(codes.PermissionDenied, "API keys cannot call this method")
+	}
+	if !p.HasScope(need) {
+		return status.Error(codes.PermissionDenied, "requires scope: "+need)
+	}
+	return nil
+}
+
+// limit takes a token for the call and reports the bucket in the
+// ratelimit-* response headers. If the store fails the call is let
+// through.
+func (g guard) limit(ctx context.Context, client string, write bool) error {
+	limit, res, err := g.limiter.Take(ctx, client, write)
+	if err != nil {
+		slog.WarnContext(ctx, "ratelimit: store failed, call let through", "error", err)
+		return nil
+	}
+	md := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(limit.Burst),
+		"ratelimit-remaining", strconv.Itoa(res.Remaining),
+		"ratelimit-reset", ratelimit.Seconds(res.Reset),
+	)
+	if !res.Allowed {
+		md.Set("retry-after", ratelimit.Seconds(res.RetryAfter))
+	}
+	grpc.SetHeader(ctx, md)
+	if !res.Allowed {
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %ss", ratelimit.Seconds(res.RetryAfter))
+	}
+	return nil
+}
+
+// writes reports whether method changes data and so counts against the
+// write budget.
+func writes(method string) bool {
+	switch path.Base(method) {
+	case "Create", "Update", "Delete":
+		return true
+	}
+	return false
+}
+
+// peerIP returns the address the call came from.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,128 @@
+package grpcapi
+
+import (
+	"school-api/internal/enrollment"
+	"school-api/internal/models"
+	"school-api/internal/pb"
+)
+
+func toPBList[M any, P any](items []M, fn func(M) *P) []*P {
+	out := make([]*P, len(items))
+	for i, item := range items {
+		out[i] = fn(item)
+	}
+	return out
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	n := int32(*v)
+	return &n
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
+
+func classToPB(m models.Class) *pb.Class {
+	return &pb.Class{Id: uint64(m.ID), Grade: int32(m.Grade), Letter: m.Letter, GraduatedYear: int32Ptr(m.GraduatedYear)}
+}
+
+func classFromPB(p *pb.Class) models.Class {
+	return models.Class{Grade: int(p.GetGrade()), Letter: p.GetLetter()}
+}
+
+func studentToPB(m models.Student) *pb.Student {
+	return &pb.Student{
+		Id: uint64(m.ID), ClassId: uint64(m.ClassID),
+		FirstName: m.FirstName, LastName: m.LastName, Patronymic: m.Patronymic,
+		Status: m.Status,
+	}
+}
+
+func studentFromPB(p *pb.Student) models.Student {
+	return models.Student{
+		ClassID:   uint(p.GetClassId()),
+		FirstName: p.GetFirstName(), LastName: p.GetLastName(), Patronymic: p.GetPatronymic(),
+	}
+}
+
+func teacherToPB(m models.Teacher) *pb.Teacher {
+	return &pb.Teacher{Id: uint64(m.ID), FirstName: m.FirstName, LastName: m.LastName, Patronymic: m.Patronymic}
+}
+
+func teacherFromPB(p *pb.Teacher) models.Teacher {
+	return models.Teacher{FirstName: p.GetFirstName(), LastName: p.GetLastName(), Patronymic: p.GetPatronymic()}
+}
+
+func subjectToPB(m models.Subject) *pb.Subject {
+	return &pb.Subject{Id: uint64(m.ID), SubjectName: m.SubjectName}
+}
+
+func subjectFromPB(p *pb.Subject) models.Subject {
+	return models.Subject{SubjectName: p.GetSubjectName()}
+}
+
+func teacherAssignmentToPB(m models.TeacherAssignment) *pb.TeacherAssignment {
+	return &pb.TeacherAssignment{Id: uint64(m.ID), TeacherId: uint64(m.TeacherID), SubjectId: uint64(m.SubjectID)}
+}
+
+func teacherAssignmentFromPB(p *pb.TeacherAssignment) models.TeacherAssignment {
+	return models.TeacherAssignment{TeacherID: uint(p.GetTeacherId()), SubjectID: uint(p.GetSubjectId())}
+}
+
+func lessonScheduleToPB(m models.LessonSchedule) *pb.LessonSchedule {
+	return &pb.LessonSchedule{
+		Id: uint64(m.ID), SubjectId: uint64(m.SubjectID), Weekday: int32(m.Weekday), Number: int32(m.Number),
+		ClassId: uint64(m.ClassID), TeacherId: uint64(m.TeacherID),
+	}
+}
+
+func lessonScheduleFromPB(p *pb.LessonSchedule) models.LessonSchedule {
+	return models.LessonSchedule{
+		SubjectID: uint(p.GetSubjectId()), Weekday: int(p.GetWeekday()), Number: int(p.GetNumber()),
+		ClassID: uint(p.GetClassId()), TeacherID: uint(p.GetTeacherId()),
+	}
+}
+
+func lessonLogToPB(m models.LessonLog) *pb.LessonLog {
+	return &pb.LessonLog{
+		Id: uint64(m.ID), SubjectId: uint64(m.SubjectID), Date: enrollment.DateOnly(m.Date), Number: int32(m.Number),
+		ClassId: uint64(m.ClassID), TeacherId: uint64(m.TeacherID),
+	}
+}
+
+func lessonLogFromPB(p *pb.LessonLog) models.LessonLog {
+	return models.LessonLog{
+		SubjectID: uint(p.GetSubjectId()), Date: p.GetDate(), Number: int(p.GetNumber()),
+		ClassID: uint(p.GetClassId()), TeacherID: uint(p.GetTeacherId()),
+	}
+}
+
+func studentLessonToPB(m models.StudentLesson) *pb.StudentLesson {
+	return &pb.StudentLesson{
+		Id: uint64(m.ID), StudentId: uint64(m.StudentID), LessonId: uint64(m.LessonID),
+		Grade: int32Ptr(m.Grade), AttendanceStatus: m.AttendanceStatus,
+	}
+}
+
+func studentLessonFromPB(p *pb.StudentLesson) models.StudentLesson {
+	return models.StudentLesson{
+		StudentID: uint(p.GetStudentId()), LessonID: uint(p.GetLessonId()),
+		Grade: intPtr(p.Grade), AttendanceStatus: p.GetAttendanceStatus(),
+	}
+}
+
+func attendanceStatusToPB(m models.AttendanceStatus) *pb.AttendanceStatus {
+	return &pb.AttendanceStatus{Code: m.Code, Description: m.Description}
+}
+
+func attendanceStatusFromPB(p *pb.AttendanceStatus) models.AttendanceStatus {
+	return models.AttendanceStatus{Code: p.GetCode(), Description: p.GetDescription()}
+}
@@ -0,0 +1,75 @@
+// Package grpcapi serves the journal over gRPC. Every RPC delegates to the
+// service package, the same code behind the REST handlers.
+package grpcapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"school-api/internal/cache"
+	"school-api/internal/enrollment"
+	"school-api/internal/events"
+	"school-api/internal/pb"
+	"school-api/internal/service"
+)
+
+// streamBatch is how many rows streaming RPCs load per query.
+const streamBatch = 500
+
+// NewServer returns a gRPC server with all journal services registered.
+// Writes to cached reference data invalidate rc, which may be nil.
+func NewServer(db *gorm.DB, bus *events.Bus, rc *cache.Cache, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryLogger),
+		grpc.ChainStreamInterceptor(streamLogger),
+	}, opts...)
+	s := grpc.NewServer(opts...)
+	pb.RegisterClassServiceServer(s, classServer{svc: service.Classes{DB: db}, cache: rc})
+	pb.RegisterStudentServiceServer(s, studentServer{svc: service.Students{DB: db}})
+	pb.RegisterTeacherServiceServer(s, teacherServer{svc: service.Teachers{DB: db}})
+	pb.RegisterSubjectServiceServer(s, subjectServer{svc: service.Subjects{DB: db}, cache: rc})
+	pb.RegisterTeacherAssignmentServiceServer(s, teacherAssignmentServer{svc: service.TeacherAssignments{DB: db}})
+	pb.RegisterLessonScheduleServiceServer(s, lessonScheduleServer{svc: service.LessonSchedules{DB: db, Events: bus}})
+	pb.RegisterLessonLogServiceServer(s, lessonLogServer{svc: service.LessonLogs{DB: db, Events: bus}})
+	pb.RegisterStudentLessonServiceServer(s, studentLessonServer{svc: service.StudentLessons{DB: db, Events: bus}})
+	pb.RegisterAttendanceStatusServiceServer(s, attendanceStatusServer{svc: service.AttendanceStatuses{DB: db}, cache: rc})
+	reflection.Register(s)
+	return s
+}
+
+// toStatus maps a service error to the matching gRPC status.
+func toStatus(err error) error {
+	var conflict *service.ConflictError
+	var invalid *service.InvalidError
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		return status.Error(codes.NotFound, "resource not found")
+	case errors.As(err, &conflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.As(err, &invalid):
+		return status.Error(codes.InvalidArgument, invalid.Message())
+	default:
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// checkDate validates an optional YYYY-MM-DD request field.
+func checkDate(name, v string) error {
+	if v == "" {
+		return nil
+	}
+	if _, err := time.Parse(enrollment.DateLayout, v); err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("%s must be a date in YYYY-MM-DD format", name))
+	}
+	return nil
+}
@@ -0,0 +1,413 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"school-api/internal/cache"
+	"school-api/internal/enrollment"
+	"school-api/internal/models"
+	"school-api/internal/pb"
+	"school-api/internal/service"
+)
+
+// reply converts a service result into an RPC response.
+func reply[M any, P any](item M, err error, fn func(M) *P) (*P, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return fn(item), nil
+}
+
+func empty(err error) (*emptypb.Empty, error) {
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// invalidate drops the cached REST responses for resource after a
+// successful write.
+func invalidate(ctx context.Context, rc *cache.Cache, resource string, err error) {
+	if err == nil {
+		rc.Invalidate(ctx, resource)
+	}
+}
+
+type classServer struct {
+	pb.UnimplementedClassServiceServer
+	svc   service.Classes
+	cache *cache.Cache
+}
+
+func (s classServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListClassesResponse, error) {
+	items, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListClassesResponse{Classes: toPBList(items, classToPB)}, nil
+}
+
+func (s classServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.Class, error) {
+	item, err := s.svc.Get(ctx, uint(req.GetId()))
+	return reply(item, err, classToPB)
+}
+
+func (s classServer) Create(ctx context.Context, req *pb.Class) (*pb.Class, error) {
+	item, err := s.svc.Create(ctx, classFromPB(req))
+	invalidate(ctx, s.cache, cache.Classes, err)
+	return reply(item, err, classToPB)
+}
+
+func (s classServer) Update(ctx context.Context, req *pb.Class) (*pb.Class, error) {
+	item, err := s.svc.Update(ctx, uint(req.GetId()), classFromPB(req))
+	invalidate(ctx, s.cache, cache.Classes, err)
+	return reply(item, err, classToPB)
+}
+
+func (s classServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	err := s.svc.Delete(ctx, uint(req.GetId()))
+	invalidate(ctx, s.cache, cache.Classes, err)
+	return empty(err)
+}
+
+// Students returns the class roster on req.date (default today).
+func (s classServer) Students(ctx context.Context, req *pb.ClassStudentsRequest) (*pb.ListStudentsResponse, error) {
+	if err := checkDate("date", req.GetDate()); err != nil {
+		return nil, err
+	}
+	date := req.GetDate()
+	if date == "" {
+		date = enrollment.Today()
+	}
+	items, err := s.svc.Students(ctx, uint(req.GetId()), date)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListStudentsResponse{Students: toPBList(items, studentToPB)}, nil
+}
+
+type studentServer struct {
+	pb.UnimplementedStudentServiceServer
+	svc service.Students
+}
+
+func (s studentServer) List(ctx context.Context, req *pb.ListStudentsRequest) (*pb.ListStudentsResponse, error) {
+	if err := checkDate("date", req.GetDate()); err != nil {
+		return nil, err
+	}
+	var filter service.StudentFilter
+	if req.ClassId != nil {
+		id := uint(req.GetClassId())
+		filter = service.StudentFilter{ClassID: &id, Date: req.GetDate()}
+	}
+	items, err := s.svc.List(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListStudentsResponse{Students: toPBList(items, studentToPB)}, nil
+}
+
+func (s studentServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.Student, error) {
+	item, err := s.svc.Get(ctx, uint(req.GetId()))
+	return reply(item, err, studentToPB)
+}
+
+func (s studentServer) Create(ctx context.Context, req *pb.Student) (*pb.Student, error) {
+	item, err := s.svc.Create(ctx, studentFromPB(req))
+	return reply(item, err, studentToPB)
+}
+
+func (s studentServer) Update(ctx context.Context, req *pb.Student) (*pb.Student, error) {
+	item, err := s.svc.Update(ctx, uint(req.GetId()), studentFromPB(req))
+	return reply(item, err, studentToPB)
+}
+
+func (s studentServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	return empty(s.svc.Delete(ctx, uint(req.GetId())))
+}
+
+type teacherServer struct {
+	pb.UnimplementedTeacherServiceServer
+	svc service.Teachers
+}
+
+func (s teacherServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListTeachersResponse, error) {
+	items, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListTeachersResponse{Teachers: toPBList(items, teacherToPB)}, nil
+}
+
+func (s teacherServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.Teacher, error) {
+	item, err := s.svc.Get(ctx, uint(req.GetId()))
+	return reply(item, err, teacherToPB)
+}
+
+func (s teacherServer) Create(ctx context.Context, req *pb.Teacher) (*pb.Teacher, error) {
+	item, err := s.svc.Create(ctx, teacherFromPB(req))
+	return reply(item, err, teacherToPB)
+}
+
+func (s teacherServer) Update(ctx context.Context, req *pb.Teacher) (*pb.Teacher, error) {
+	item, err := s.svc.Update(ctx, uint(req.GetId()), teacherFromPB(req))
+	return reply(item, err, teacherToPB)
+}
+
+func (s teacherServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	return empty(s.svc.Delete(ctx, uint(req.GetId())))
+}
+
+type subjectServer struct {
+	pb.UnimplementedSubjectServiceServer
+	svc   service.Subjects
+	cache *cache.Cache
+}
+
+func (s subjectServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListSubjectsResponse, error) {
+	items, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListSubjectsResponse{Subjects: toPBList(items, subjectToPB)}, nil
+}
+
+func (s subjectServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.Subject, error) {
+	item, err := s.svc.Get(ctx, uint(req.GetId()))
+	return reply(item, err, subjectToPB)
+}
+
+func (s subjectServer) Create(ctx context.Context, req *pb.Subject) (*pb.Subject, error) {
+	item, err := s.svc.Create(ctx, subjectFromPB(req))
+	invalidate(ctx, s.cache, cache.Subjects, err)
+	return reply(item, err, subjectToPB)
+}
+
+func (s subjectServer) Update(ctx context.Context, req *pb.Subject) (*pb.Subject, error) {
+	item, err := s.svc.Update(ctx, uint(req.GetId()), subjectFromPB(req))
+	invalidate(ctx, s.cache, cache.Subjects, err)
+	return reply(item, err, subjectToPB)
+}
+
+func (s subjectServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	err := s.svc.Delete(ctx, uint(req.GetId()))
+	invalidate(ctx, s.cache, cache.Subjects, err)
+	return empty(err)
+}
+
+type teacherAssignmentServer struct {
+	pb.UnimplementedTeacherAssignmentServiceServer
+	svc service.TeacherAssignments
+}
+
+func (s teacherAssignmentServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListTeacherAssignmentsResponse, error) {
+	items, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListTeacherAssignmentsResponse{TeacherAssignments: toPBList(items, teacherAssignmentToPB)}, nil
+}
+
+func (s teacherAssignmentServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.TeacherAssignment, error) {
+	item, err := s.svc.Get(ctx, uint(req.GetId()))
+	return reply(item, err, teacherAssignmentToPB)
+}
+
+func (s teacherAssignmentServer) Create(ctx context.Context, req *pb.TeacherAssignment) (*pb.TeacherAssignment, error) {
+	item, err := s.svc.Create(ctx, teacherAssignmentFromPB(req))
+	return reply(item, err, teacherAssignmentToPB)
+}
+
+func (s teacherAssignmentServer) Update(ctx context.Context, req *pb.TeacherAssignment) (*pb.TeacherAssignment, error) {
+	item, err := s.svc.Update(ctx, uint(req.GetId()), teacherAssignmentFromPB(req))
+	return reply(item, err, teacherAssignmentToPB)
+}
+
+func (s teacherAssignmentServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	return empty(s.svc.Delete(ctx, uint(req.GetId())))
+}
+
+type lessonScheduleServer struct {
+	pb.UnimplementedLessonScheduleServiceServer
+	svc service.LessonSchedules
+}
+
+func (s lessonScheduleServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListLessonSchedulesResponse, error) {
+	items, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListLessonSchedulesResponse{LessonSchedules: toPBList(items, lessonScheduleToPB)}, nil
+}
+
+func (s lessonScheduleServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.LessonSchedule, error) {
+	item, err := s.svc.Get(ctx, uint(req.GetId()))
+	return reply(item, err, lessonScheduleToPB)
+}
+
+func (s lessonScheduleServer) Create(ctx context.Context, req *pb.LessonSchedule) (*pb.LessonSchedule, error) {
+	item, err := s.svc.Create(ctx, lessonScheduleFromPB(req))
+	return reply(item, err, lessonScheduleToPB)
+}
+
+func (s lessonScheduleServer) Update(ctx context.Context, req *pb.LessonSchedule) (*pb.LessonSchedule, error) {
+	item, err := s.svc.Update(ctx, uint(req.GetId()), lessonScheduleFromPB(req))
+	return reply(item, err, lessonScheduleToPB)
+}
+
+func (s lessonScheduleServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	return empty(s.svc.Delete(ctx, uint(req.GetId())))
+}
+
+type lessonLogServer struct {
+	pb.UnimplementedLessonLogServiceServer
+	svc service.LessonLogs
+}
+
+func (s lessonLogServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListLessonLogsResponse, error) {
+	items, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListLessonLogsResponse{LessonLogs: toPBList(items, lessonLogToPB)}, nil
+}
+
+func (s lessonLogServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.LessonLog, error) {
+	item, err := s.svc.Get(ctx, uint(req.GetId()))
+	return reply(item, err, lessonLogToPB)
+}
+
+func (s lessonLogServer) Create(ctx context.Context, req *pb.LessonLog) (*pb.LessonLog, error) {
+	item, err := s.svc.Create(ctx, lessonLogFromPB(req))
+	return reply(item, err, lessonLogToPB)
+}
+
+func (s lessonLogServer) Update(ctx context.Context, req *pb.LessonLog) (*pb.LessonLog, error) {
+	item, err := s.svc.Update(ctx, uint(req.GetId()), lessonLogFromPB(req))
+	return reply(item, err, lessonLogToPB)
+}
+
+func (s lessonLogServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	return empty(s.svc.Delete(ctx, uint(req.GetId())))
+}
+
+// Stream sends the matching lessons one by one, loading them in batches.
+func (s lessonLogServer) Stream(req *pb.StreamLessonLogsRequest, stream pb.LessonLogService_StreamServer) error {
+	if err := checkDate("from", req.GetFrom()); err != nil {
+		return err
+	}
+	if err := checkDate("to", req.GetTo()); err != nil {
+		return err
+	}
+	filter := service.LessonLogFilter{ClassID: uint(req.GetClassId()), From: req.GetFrom(), To: req.GetTo()}
+	err := s.svc.Each(stream.Context(), filter, streamBatch, func(batch []models.LessonLog) error {
+		for _, item := range batch {
+			if err := stream.Send(lessonLogToPB(item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+type studentLessonServer struct {
+	pb.UnimplementedStudentLessonServiceServer
+	svc service.StudentLessons
+}
+
+func (s studentLessonServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListStudentLessonsResponse, error) {
+	items, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListStudentLessonsResponse{StudentLessons: toPBList(items, studentLessonToPB)}, nil
+}
+
+func (s studentLessonServer) Get(ctx context.Context, req *pb.IdRequest) (*pb.StudentLesson, error) {
+	item, err := s.svc.Get(ctx, uint(req.GetId()))
+	return reply(item, err, studentLessonToPB)
+}
+
+func (s studentLessonServer) Create(ctx context.Context, req *pb.StudentLesson) (*pb.StudentLesson, error) {
+	item, err := s.svc.Create(ctx, studentLessonFromPB(req))
+	return reply(item, err, studentLessonToPB)
+}
+
+func (s studentLessonServer) Update(ctx context.Context, req *pb.StudentLesson) (*pb.StudentLesson, error) {
+	item, err := s.svc.Update(ctx, uint(req.GetId()), studentLessonFromPB(req))
+	return reply(item, err, studentLessonToPB)
+}
+
+func (s studentLessonServer) Delete(ctx context.Context, req *pb.IdRequest) (*emptypb.Empty, error) {
+	return empty(s.svc.Delete(ctx, uint(req.GetId())))
+}
+
+// Stream sends the entries whose lesson matches the filter one by one,
+// loading them in batches.
+func (s studentLessonServer) Stream(req *pb.StreamStudentLessonsRequest, stream pb.StudentLessonService_StreamServer) error {
+	if err := checkDate("from", req.GetFrom()); err != nil {
+		return err
+	}
+	if err := checkDate("to", req.GetTo()); err != nil {
+		return err
+	}
+	filter := service.StudentLessonFilter{
+		StudentID: uint(req.GetStudentId()), ClassID: uint(req.GetClassId()),
+		From: req.GetFrom(), To: req.GetTo(),
+	}
+	err := s.svc.Each(stream.Context(), filter, streamBatch, func(batch []models.StudentLesson) error {
+		for _, item := range batch {
+			if err := stream.Send(studentLessonToPB(item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+type attendanceStatusServer struct {
+	pb.UnimplementedAttendanceStatusServiceServer
+	svc   service.AttendanceStatuses
+	cache *cache.Cache
+}
+
+func (s attendanceStatusServer) List(ctx context.Context, req *pb.ListRequest) (*pb.ListAttendanceStatusesResponse, error) {
+	items, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ListAttendanceStatusesResponse{AttendanceStatuses: toPBList(items, attendanceStatusToPB)}, nil
+}
+
+func (s attendanceStatusServer) Get(ctx context.Context, req *pb.CodeRequest) (*pb.AttendanceStatus, error) {
+	item, err := s.svc.Get(ctx, req.GetCode())
+	return reply(item, err, attendanceStatusToPB)
+}
+
+func (s attendanceStatusServer) Create(ctx context.Context, req *pb.AttendanceStatus) (*pb.AttendanceStatus, error) {
+	item, err := s.svc.Create(ctx, attendanceStatusFromPB(req))
+	invalidate(ctx, s.cache, cache.AttendanceStatuses, err)
+	return reply(item, err, attendanceStatusToPB)
+}
+
+func (s attendanceStatusServer) Update(ctx context.Context, req *pb.AttendanceStatus) (*pb.AttendanceStatus, error) {
+	item, err := s.svc.Update(ctx, req.GetCode(), attendanceStatusFromPB(req))
+	invalidate(ctx, s.cache, cache.AttendanceStatuses, err)
+	return reply(item, err, attendanceStatusToPB)
+}
+
+func (s attendanceStatusServer) Delete(ctx context.Context, req *pb.CodeRequest) (*emptypb.Empty, error) {
+	err := s.svc.Delete(ctx, req.GetCode())
+	invalidate(ctx, s.cache, cache.AttendanceStatuses, err)
+	return empty(err)
+}
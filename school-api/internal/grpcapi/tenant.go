@@ -0,0 +1,63 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"school-api/internal/auth"
+	"school-api/internal/tenant"
+)
+
+// Tenant returns the server options that run every call in the caller's
+// school, resolved like a REST request: the school of the credentials
+// stored by Auth wins, and an :authority host naming another school is
+// refused. Only anonymous calls take the school from the host. The
+// options must come after those of Auth.
+func Tenant(r tenant.Resolver) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, err := withSchool(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := withSchool(ss.Context(), r)
+			if err != nil {
+				return err
+			}
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
+
+func withSchool(ctx context.Context, r tenant.Resolver) (context.Context, error) {
+	var host string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(":authority"); len(v) > 0 {
+			host = v[0]
+		}
+	}
+	var p *auth.Principal
+	if v, ok := auth.PrincipalFrom(ctx); ok {
+		p = &v
+	}
+	id, err := r.Resolve(ctx, p, host)
+	switch {
+	case errors.Is(err, tenant.ErrUnknownSchool):
+		return nil, status.Error(codes.NotFound, "unknown school")
+	case errors.Is(err, tenant.ErrWrongSchool):
+		return nil, status.Error(codes.PermissionDenied, "credentials belong to another school")
+	case errors.Is(err, tenant.ErrNoSchool):
+		return nil, status.Error(codes.InvalidArgument, "cannot tell which school the call is for")
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return tenant.WithSchool(ctx, id), nil
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+    "fmt"
+    "net/http"
+    "slices"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "school-api/internal/apikey"
+    "school-api/internal/models"
+    "school-api/internal/service"
+)
+
+// APIKeyHandler manages API keys. Register expects r to already require
+// an admin token.
+type APIKeyHandler struct{ DB *gorm.DB }
+
+func (h APIKeyHandler) Register(r *gin.RouterGroup) {
+    r.GET("/api-keys", h.List)
+    r.POST("/api-keys", h.Create)
+    r.GET("/api-keys/:id", h.Get)
+    r.DELETE("/api-keys/:id", h.Revoke)
+}
+
+type apiKeyInput struct {
+    Name      string     `json:"name" binding:"required,max=100"`
+    Scopes    []string   `json:"scopes" binding:"required,min=1"`
+    ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (in apiKeyInput) validate() error {
+    for _, s := range in.Scopes {
+        if !slices.Contains(apikey.Scopes, s) {
+            return fmt.Errorf("unknown scope %q", s)
+        }
+    }
+    if in.ExpiresAt != nil && !in.ExpiresAt.After(time.Now()) {
+        return fmt.Errorf("expires_at must be in the future")
+    }
+    return nil
+}
+
+// createdAPIKey is returned once on creation; Key cannot be read again.
+type createdAPIKey struct {
+    models.APIKey
+    Key string `json:"key"`
+}
+
+func (h APIKeyHandler) List(c *gin.Context) {
+    var items []models.APIKey
+    if err := h.DB.WithContext(c.Request.Context()).Order("id").Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+func (h APIKeyHandler) Create(c *gin.Context) {
+    var input apiKeyInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    if err := input.validate(); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    key, prefix, hash, err := apikey.Generate()
+    if err != nil {
+        respondError(c, err)
+        return
+    }
+    item := models.APIKey{
+        Name:      input.Name,
+        Prefix:    prefix,
+        Hash:      hash,
+        Scopes:    strings.Join(slices.Compact(slices.Sorted(slices.Values(input.Scopes))), ","),
+        ExpiresAt: input.ExpiresAt,
+    }
+    if err := h.DB.WithContext(c.Request.Context()).Create(&item).Error; err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(http.StatusCreated, createdAPIKey{APIKey: item, Key: key})
+}
+
+func (h APIKeyHandler) Get(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.APIKey
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        if err == gorm.ErrRecordNotFound {
+            errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        } else {
+            respondError(c, err)
+        }
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+// Revoke disables a key for good. The row is kept for the audit trail;
+// revoking twice is a no-op.
+func (h APIKeyHandler) Revoke(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    res := h.DB.WithContext(c.Request.Context()).Model(&models.APIKey{}).
+        Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+    if res.Error != nil {
+        respondError(c, res.Error)
+        return
+    }
+    if res.RowsAffected == 0 {
+        var n int64
+        if err := h.DB.WithContext(c.Request.Context()).Model(&models.APIKey{}).Where("id = ?", id).Count(&n).Error; err != nil {
+            respondError(c, err)
+            return
+        }
+        if n == 0 {
+            errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+            return
+        }
+    }
+    c.Status(http.StatusNoContent)
+}
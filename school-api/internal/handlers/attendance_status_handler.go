@@ -2,84 +2,90 @@ package handlers
 
 import (
     "net/http"
+
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
+    "school-api/internal/cache"
     "school-api/internal/models"
+    "school-api/internal/service"
 )
 
-type AttendanceStatusHandler struct{ DB *gorm.DB }
+type AttendanceStatusHandler struct {
+    DB    *gorm.DB
+    Cache *cache.Cache // optional
+}
 
 func (h AttendanceStatusHandler) Register(r *gin.RouterGroup) {
     r.GET("/attendance-statuses", h.List)
-    r.POST("/attendance-statuses", h.Create)
     r.GET("/attendance-statuses/:code", h.Get)
+}
+
+// RegisterWrites adds the routes that change the statuses. They are
+// shared by all schools, so r must be limited to operators.
+func (h AttendanceStatusHandler) RegisterWrites(r *gin.RouterGroup) {
+    r.POST("/attendance-statuses", h.Create)
     r.PUT("/attendance-statuses/:code", h.Update)
     r.DELETE("/attendance-statuses/:code", h.Delete)
 }
 
+func (h AttendanceStatusHandler) service() service.AttendanceStatuses {
+    return service.AttendanceStatuses{DB: h.DB}
+}
+
 func (h AttendanceStatusHandler) List(c *gin.Context) {
-    var items []models.AttendanceStatus
-    if err := h.DB.Find(&items).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        return
-    }
-    c.JSON(http.StatusOK, gin.H{"data": items})
+    ctx := c.Request.Context()
+    cachedJSON(c, h.Cache, cache.ListKey(ctx, cache.AttendanceStatuses), func() (any, error) {
+        items, err := h.service().List(ctx)
+        return gin.H{"data": items}, err
+    })
 }
 
 func (h AttendanceStatusHandler) Create(c *gin.Context) {
     var input models.AttendanceStatus
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    if err := h.DB.Create(&input).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Create(c.Request.Context(), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
-    c.JSON(http.StatusCreated, input)
+    writeThrough(c, h.Cache, cache.AttendanceStatuses, item.Code, item)
+    c.JSON(http.StatusCreated, item)
 }
 
 func (h AttendanceStatusHandler) Get(c *gin.Context) {
     code := c.Param("code")
-    var item models.AttendanceStatus
-    if err := h.DB.First(&item, "code = ?", code).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        } else {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        }
-        return
-    }
-    c.JSON(http.StatusOK, item)
+    ctx := c.Request.Context()
+    cachedJSON(c, h.Cache, cache.ItemKey(ctx, cache.AttendanceStatuses, code), func() (any, error) {
+        item, err := h.service().Get(ctx, code)
+        return item, err
+    })
 }
 
 func (h AttendanceStatusHandler) Update(c *gin.Context) {
     code := c.Param("code")
-    var item models.AttendanceStatus
-    if err := h.DB.First(&item, "code = ?", code).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        return
-    }
     var input models.AttendanceStatus
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    item.Description = input.Description
-    if err := h.DB.Save(&item).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Update(c.Request.Context(), code, input)
+    if err != nil {
+        respondError(c, err)
         return
     }
+    writeThrough(c, h.Cache, cache.AttendanceStatuses, item.Code, item)
     c.JSON(http.StatusOK, item)
 }
 
 func (h AttendanceStatusHandler) Delete(c *gin.Context) {
     code := c.Param("code")
-    if err := h.DB.Delete(&models.AttendanceStatus{}, "code = ?", code).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    if err := h.service().Delete(c.Request.Context(), code); err != nil {
+        respondError(c, err)
         return
     }
+    h.Cache.Invalidate(c.Request.Context(), cache.AttendanceStatuses)
     c.Status(http.StatusNoContent)
 }
-
-
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+    "net/http"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "school-api/internal/cache"
+)
+
+// cachedJSON writes the value load returns, read through rc under key. The
+// response carries Cache-Control and Last-Modified, and a request whose
+// If-Modified-Since is not older than the cached entry gets 304 without a
+// body. Without a cache it just writes the value.
+func cachedJSON(c *gin.Context, rc *cache.Cache, key string, load func() (any, error)) {
+    if rc == nil {
+        v, err := load()
+        if err != nil {
+            respondError(c, err)
+            return
+        }
+        c.JSON(http.StatusOK, v)
+        return
+    }
+    e, err := rc.Load(c.Request.Context(), key, load)
+    if err != nil {
+        respondError(c, err)
+        return
+    }
+    modified := e.Modified.UTC().Truncate(time.Second)
+    c.Header("Cache-Control", rc.CacheControl())
+    c.Header("Last-Modified", modified.Format(http.TimeFormat))
+    if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !modified.After(since) {
+        c.Status(http.StatusNotModified)
+        return
+    }
+    c.Data(http.StatusOK, "application/json; charset=utf-8", e.Body)
+}
+
+// writeThrough drops the cached copies of resource after a write and
+// caches the created or updated item under id.
+func writeThrough(c *gin.Context, rc *cache.Cache, resource, id string, item any) {
+    if rc == nil {
+        return
+    }
+    ctx := c.Request.Context()
+    rc.Invalidate(ctx, resource)
+    rc.Put(ctx, cache.ItemKey(ctx, resource, id), item)
+}
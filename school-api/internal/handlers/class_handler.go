@@ -6,10 +6,15 @@ import (
 
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
+    "school-api/internal/cache"
     "school-api/internal/models"
+    "school-api/internal/service"
 )
 
-type ClassHandler struct{ DB *gorm.DB }
+type ClassHandler struct {
+    DB    *gorm.DB
+    Cache *cache.Cache // optional
+}
 
 func (h ClassHandler) Register(r *gin.RouterGroup) {
     r.GET("/classes", h.List)
@@ -17,73 +22,83 @@ func (h ClassHandler) Register(r *gin.RouterGroup) {
     r.GET("/classes/:id", h.Get)
     r.PUT("/classes/:id", h.Update)
     r.DELETE("/classes/:id", h.Delete)
+    r.GET("/classes/:id/students", h.Students)
+}
+
+func (h ClassHandler) service() service.Classes {
+    return service.Classes{DB: h.DB}
 }
 
 func (h ClassHandler) List(c *gin.Context) {
-    var items []models.Class
-    if err := h.DB.Find(&items).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        return
-    }
-    c.JSON(http.StatusOK, gin.H{"data": items})
+    ctx := c.Request.Context()
+    cachedJSON(c, h.Cache, cache.ListKey(ctx, cache.Classes), func() (any, error) {
+        items, err := h.service().List(ctx)
+        return gin.H{"data": items}, err
+    })
 }
 
 func (h ClassHandler) Create(c *gin.Context) {
     var input models.Class
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    input.ID = 0
-    if err := h.DB.Create(&input).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Create(c.Request.Context(), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
-    c.JSON(http.StatusCreated, input)
+    writeThrough(c, h.Cache, cache.Classes, strconv.Itoa(int(item.ID)), item)
+    c.JSON(http.StatusCreated, item)
 }
 
 func (h ClassHandler) Get(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.Class
-    if err := h.DB.First(&item, id).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        } else {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        }
-        return
-    }
-    c.JSON(http.StatusOK, item)
+    ctx := c.Request.Context()
+    cachedJSON(c, h.Cache, cache.ItemKey(ctx, cache.Classes, strconv.Itoa(id)), func() (any, error) {
+        item, err := h.service().Get(ctx, uint(id))
+        return item, err
+    })
 }
 
 func (h ClassHandler) Update(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.Class
-    if err := h.DB.First(&item, id).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        return
-    }
     var input models.Class
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    item.Grade = input.Grade
-    item.Letter = input.Letter
-    if err := h.DB.Save(&item).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Update(c.Request.Context(), uint(id), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
+    writeThrough(c, h.Cache, cache.Classes, strconv.Itoa(int(item.ID)), item)
     c.JSON(http.StatusOK, item)
 }
 
 func (h ClassHandler) Delete(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    if err := h.DB.Delete(&models.Class{}, id).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    if err := h.service().Delete(c.Request.Context(), uint(id)); err != nil {
+        respondError(c, err)
         return
     }
+    h.Cache.Invalidate(c.Request.Context(), cache.Classes)
     c.Status(http.StatusNoContent)
 }
 
-
+// Students returns the class roster as of ?date= (YYYY-MM-DD, default
+// today), based on enrollment history rather than the current class_id.
+func (h ClassHandler) Students(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    date, ok := queryDate(c, "date")
+    if !ok {
+        return
+    }
+    items, err := h.service().Students(c.Request.Context(), uint(id), date)
+    if err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
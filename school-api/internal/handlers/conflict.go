@@ -0,0 +1,43 @@
+package handlers
+
+import (
+    "errors"
+    "log/slog"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "school-api/internal/logging"
+    "school-api/internal/service"
+)
+
+// respondError writes the response for an error returned by the service
+// layer: 404 for a missing row, 409 pointing at the existing resource for a
+// natural-key conflict, 422 for a rejected write and 500 otherwise. Only
+// the 500 is logged as an error; its cause never reaches the client.
+func respondError(c *gin.Context, err error) {
+    var conflict *service.ConflictError
+    var invalid *service.InvalidError
+    switch {
+    case errors.Is(err, service.ErrNotFound):
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+    case errors.As(err, &conflict):
+        c.JSON(http.StatusConflict, gin.H{
+            "error":       "Conflict",
+            "message":     "Resource with the same natural key already exists",
+            "existing_id": conflict.ExistingID,
+            "request_id":  logging.RequestID(c.Request.Context()),
+        })
+    case errors.As(err, &invalid):
+        slog.InfoContext(c.Request.Context(), "write rejected", "route", c.FullPath(), "error", err)
+        errorResponse(c, http.StatusUnprocessableEntity, "UnprocessableEntity", invalid.Message())
+    default:
+        slog.ErrorContext(c.Request.Context(), "request failed", "route", c.FullPath(), "error", err)
+        errorResponse(c, http.StatusInternalServerError, "InternalServerError", "Internal server error")
+    }
+}
+
+// errorResponse writes the JSON error body shared by all handlers. The
+// request ID lets a client report the failure for lookup in the logs.
+func errorResponse(c *gin.Context, status int, kind, message string) {
+    c.JSON(status, gin.H{"error": kind, "message": message, "request_id": logging.RequestID(c.Request.Context())})
+}
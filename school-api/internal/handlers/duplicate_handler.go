@@ -0,0 +1,30 @@
+package handlers
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    dbpkg "school-api/internal/db"
+)
+
+type DuplicateHandler struct{ DB *gorm.DB }
+
+func (h DuplicateHandler) Register(r *gin.RouterGroup) {
+    r.GET("/duplicates", h.List)
+}
+
+// List reports rows that share a natural key. Unique indexes for keys with
+// duplicates stay pending until these groups are cleaned up.
+func (h DuplicateHandler) List(c *gin.Context) {
+    items := []dbpkg.DuplicateGroup{}
+    for _, k := range dbpkg.UniqueKeys {
+        groups, err := dbpkg.FindDuplicates(h.DB.WithContext(c.Request.Context()), k)
+        if err != nil {
+            respondError(c, err)
+            return
+        }
+        items = append(items, groups...)
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
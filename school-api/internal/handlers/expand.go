@@ -0,0 +1,45 @@
+package handlers
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+)
+
+// expandPaths resolves the ?expand= query parameter. allowed maps public
+// relation names (e.g. "subject", "lesson.teacher") to GORM preload paths.
+// Preload issues one batched IN query per association, so expanding a list
+// never causes N+1 queries.
+func expandPaths(c *gin.Context, allowed map[string]string) ([]string, error) {
+    raw := c.Query("expand")
+    if raw == "" {
+        return nil, nil
+    }
+    var paths []string
+    for _, name := range strings.Split(raw, ",") {
+        name = strings.TrimSpace(name)
+        if name == "" {
+            continue
+        }
+        path, ok := allowed[name]
+        if !ok {
+            return nil, fmt.Errorf("unknown expand value %q", name)
+        }
+        paths = append(paths, path)
+    }
+    return paths, nil
+}
+
+// withExpand applies the ?expand= query parameter to the query.
+func withExpand(c *gin.Context, db *gorm.DB, allowed map[string]string) (*gorm.DB, error) {
+    paths, err := expandPaths(c, allowed)
+    if err != nil {
+        return nil, err
+    }
+    for _, p := range paths {
+        db = db.Preload(p)
+    }
+    return db, nil
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+    "encoding/json"
+    "errors"
+    "log/slog"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/graph-gophers/graphql-go"
+    "gorm.io/gorm"
+    "school-api/internal/gql"
+    "school-api/internal/logging"
+)
+
+type GraphQLHandler struct {
+    DB     *gorm.DB
+    Schema *graphql.Schema
+}
+
+func (h GraphQLHandler) Register(r *gin.RouterGroup) {
+    r.GET("/graphql", h.Query)
+    r.POST("/graphql", h.Query)
+}
+
+type graphQLRequest struct {
+    Query         string                 `json:"query" form:"query"`
+    OperationName string                 `json:"operationName" form:"operationName"`
+    Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query executes a GraphQL query sent as a JSON body (POST) or as ?query=,
+// ?operationName= and ?variables= (GET).
+func (h GraphQLHandler) Query(c *gin.Context) {
+    var req graphQLRequest
+    if c.Request.Method == http.MethodPost {
+        if err := c.ShouldBindJSON(&req); err != nil {
+            errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+            return
+        }
+    } else {
+        req.Query = c.Query("query")
+        req.OperationName = c.Query("operationName")
+        if raw := c.Query("variables"); raw != "" {
+            if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+                errorResponse(c, http.StatusBadRequest, "BadRequest", "variables must be a JSON object")
+                return
+            }
+        }
+    }
+    if req.Query == "" {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "query is required")
+        return
+    }
+
+    ctx := gql.WithLoaders(c.Request.Context(), h.DB.WithContext(c.Request.Context()))
+    resp := h.Schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+    for _, e := range resp.Errors {
+        var argErr gql.ArgumentError
+        if e.ResolverError == nil || errors.As(e.ResolverError, &argErr) {
+            continue
+        }
+        // Resolver failures are database errors: log them, hide them
+        slog.ErrorContext(ctx, "graphql resolver failed", "path", e.Path, "error", e.ResolverError)
+        e.Message = "Internal server error"
+        e.Extensions = map[string]interface{}{"request_id": logging.RequestID(ctx)}
+    }
+    c.JSON(http.StatusOK, resp)
+}
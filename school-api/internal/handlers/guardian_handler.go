@@ -0,0 +1,194 @@
+package handlers
+
+import (
+    "errors"
+    "net/http"
+    "net/mail"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+    "school-api/internal/models"
+    "school-api/internal/service"
+)
+
+type GuardianHandler struct{ DB *gorm.DB }
+
+func (h GuardianHandler) Register(r *gin.RouterGroup) {
+    r.GET("/guardians", h.List)
+    r.POST("/guardians", h.Create)
+    r.GET("/guardians/:id", h.Get)
+    r.PUT("/guardians/:id", h.Update)
+    r.DELETE("/guardians/:id", h.Delete)
+    r.GET("/guardians/:id/students", h.Students)
+    r.PUT("/guardians/:id/students/:student_id", h.LinkStudent)
+    r.DELETE("/guardians/:id/students/:student_id", h.UnlinkStudent)
+}
+
+func (h GuardianHandler) List(c *gin.Context) {
+    var items []models.Guardian
+    if err := h.DB.WithContext(c.Request.Context()).Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+func (h GuardianHandler) Create(c *gin.Context) {
+    var input models.Guardian
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    if err := normalizeEmail(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    input.ID = 0
+    input.Students = nil
+    if err := h.DB.WithContext(c.Request.Context()).Omit(clause.Associations).Create(&input).Error; err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(http.StatusCreated, input)
+}
+
+func (h GuardianHandler) Get(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.Guardian
+    if err := h.DB.WithContext(c.Request.Context()).Preload("Students").First(&item, id).Error; err != nil {
+        if err == gorm.ErrRecordNotFound {
+            errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        } else {
+            respondError(c, err)
+        }
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+func (h GuardianHandler) Update(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.Guardian
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    var input models.Guardian
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    if err := normalizeEmail(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    item.FirstName = input.FirstName
+    item.LastName = input.LastName
+    item.Patronymic = input.Patronymic
+    item.Phone = input.Phone
+    item.Email = input.Email
+    if input.Language != "" {
+        item.Language = input.Language
+    }
+    if err := h.DB.WithContext(c.Request.Context()).Omit(clause.Associations).Save(&item).Error; err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+func (h GuardianHandler) Delete(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    err := h.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+        // Loaded first so that only a guardian of this school loses its links.
+        var item models.Guardian
+        if err := tx.First(&item, id).Error; err != nil {
+            if errors.Is(err, gorm.ErrRecordNotFound) {
+                return service.ErrNotFound
+            }
+            return err
+        }
+        if err := tx.Model(&item).Association("Students").Clear(); err != nil {
+            return err
+        }
+        return tx.Delete(&item).Error
+    })
+    if err != nil {
+        respondError(c, err)
+        return
+    }
+    c.Status(http.StatusNoContent)
+}
+
+func (h GuardianHandler) Students(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var guardian models.Guardian
+    if err := h.DB.WithContext(c.Request.Context()).First(&guardian, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    var items []models.Student
+    if err := h.DB.WithContext(c.Request.Context()).Model(&guardian).Association("Students").Find(&items); err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// LinkStudent makes the guardian a representative of the student.
+func (h GuardianHandler) LinkStudent(c *gin.Context) {
+    guardian, student, ok := h.pair(c)
+    if !ok {
+        return
+    }
+    if err := h.DB.WithContext(c.Request.Context()).Model(&guardian).Omit("Students.*").Association("Students").Append(&student); err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.Status(http.StatusNoContent)
+}
+
+func (h GuardianHandler) UnlinkStudent(c *gin.Context) {
+    guardian, student, ok := h.pair(c)
+    if !ok {
+        return
+    }
+    if err := h.DB.WithContext(c.Request.Context()).Model(&guardian).Association("Students").Delete(&student); err != nil {
+        respondError(c, err)
+        return
+    }
+    c.Status(http.StatusNoContent)
+}
+
+// pair loads the guardian and student named in the path, writing 404 if
+// either does not exist.
+func (h GuardianHandler) pair(c *gin.Context) (models.Guardian, models.Student, bool) {
+    var guardian models.Guardian
+    var student models.Student
+    if err := h.DB.WithContext(c.Request.Context()).First(&guardian, c.Param("id")).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Guardian not found")
+        return guardian, student, false
+    }
+    if err := h.DB.WithContext(c.Request.Context()).First(&student, c.Param("student_id")).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Student not found")
+        return guardian, student, false
+    }
+    return guardian, student, true
+}
+
+// normalizeEmail reduces the guardian's email to the bare address, so that
+// only a parsed address ever reaches the To header of a digest. An empty
+// email is kept: the guardian then gets no digests.
+func normalizeEmail(g *models.Guardian) error {
+    if g.Email == "" {
+        return nil
+    }
+    addr, err := mail.ParseAddress(g.Email)
+    if err != nil {
+        return errors.New("email must be a valid address")
+    }
+    g.Email = addr.Address
+    return nil
+}
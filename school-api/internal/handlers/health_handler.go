@@ -0,0 +1,97 @@
+package handlers
+
+import (
+    "context"
+    "log/slog"
+    "net/http"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "school-api/internal/buildinfo"
+    dbpkg "school-api/internal/db"
+)
+
+// readyTimeout bounds the readiness checks so a hung database fails the
+// probe instead of stalling it.
+const readyTimeout = 2 * time.Second
+
+// HealthHandler serves the probes of the container platform. They sit
+// outside /api/v1 and need no authentication.
+type HealthHandler struct{ DB *gorm.DB }
+
+func (h HealthHandler) Register(r *gin.RouterGroup) {
+    r.GET("/healthz", h.Live)
+    r.GET("/readyz", h.Ready)
+    r.GET("/version", h.Version)
+}
+
+// Live reports that the process is up and serving requests.
+func (h HealthHandler) Live(c *gin.Context) {
+    c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready reports whether the instance can serve traffic: the database
+// answers a ping and every schema migration has been applied. Failing
+// checks answer 503 naming the check; the cause is logged. Migrations
+// that wait for the data to be fixed (see dbpkg.ErrPreconditionFailed)
+// leave the instance serving: the answer is 200 with status "degraded".
+func (h HealthHandler) Ready(c *gin.Context) {
+    ctx, cancel := context.WithTimeout(c.Request.Context(), readyTimeout)
+    defer cancel()
+
+    checks := gin.H{"database": "ok", "migrations": "ok"}
+    ready, degraded := true, false
+    if err := h.ping(ctx); err != nil {
+        slog.WarnContext(ctx, "readiness: database ping failed", "error", err)
+        checks["database"] = "unreachable"
+        checks["migrations"] = "unknown"
+        ready = false
+    } else if pending, err := dbpkg.PendingMigrations(h.DB.WithContext(ctx)); err != nil {
+        slog.WarnContext(ctx, "readiness: reading migrations failed", "error", err)
+        checks["migrations"] = "unknown"
+        ready = false
+    } else if len(pending) > 0 {
+        var unblocked []string
+        blocked := map[string]string{}
+        for _, id := range pending {
+            if reason, ok := dbpkg.BlockedMigration(id); ok {
+                blocked[id] = reason
+            } else {
+                unblocked = append(unblocked, id)
+            }
+        }
+        migrations := gin.H{}
+        if len(unblocked) > 0 {
+            migrations["pending"] = unblocked
+            ready = false
+        }
+        if len(blocked) > 0 {
+            migrations["blocked"] = blocked
+            degraded = true
+        }
+        checks["migrations"] = migrations
+    }
+
+    switch {
+    case !ready:
+        c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "checks": checks})
+    case degraded:
+        c.JSON(http.StatusOK, gin.H{"status": "degraded", "checks": checks})
+    default:
+        c.JSON(http.StatusOK, gin.H{"status": "ok", "checks": checks})
+    }
+}
+
+func (h HealthHandler) ping(ctx context.Context) error {
+    sqlDB, err := h.DB.DB()
+    if err != nil {
+        return err
+    }
+    return sqlDB.PingContext(ctx)
+}
+
+// Version returns the build information of the running binary.
+func (h HealthHandler) Version(c *gin.Context) {
+    c.JSON(http.StatusOK, buildinfo.Get())
+}
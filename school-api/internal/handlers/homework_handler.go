@@ -0,0 +1,240 @@
+package handlers
+
+import (
+    "errors"
+    "fmt"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+    "school-api/internal/enrollment"
+    "school-api/internal/models"
+    "school-api/internal/service"
+)
+
+type HomeworkHandler struct{ DB *gorm.DB }
+
+var homeworkExpand = map[string]string{
+    "lesson":         "Lesson",
+    "lesson.subject": "Lesson.Subject",
+    "lesson.teacher": "Lesson.Teacher",
+}
+
+// errInvalidDueDate is returned when a due date is not a later lesson of
+// the same subject for the same class.
+var errInvalidDueDate = errors.New("due_date must be a future lesson of the same subject")
+
+func (h HomeworkHandler) Register(r *gin.RouterGroup) {
+    r.GET("/homework", h.List)
+    r.POST("/homework", h.Create)
+    r.GET("/homework/:id", h.Get)
+    r.PUT("/homework/:id", h.Update)
+    r.DELETE("/homework/:id", h.Delete)
+    r.GET("/students/:id/homework", h.ForStudent)
+}
+
+// List returns homework, optionally filtered by ?class_id= and a due date
+// range ?from= / ?to= (inclusive).
+func (h HomeworkHandler) List(c *gin.Context) {
+    q, err := withExpand(c, h.DB.WithContext(c.Request.Context()), homeworkExpand)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    from, ok := optionalQueryDate(c, "from")
+    if !ok {
+        return
+    }
+    to, ok := optionalQueryDate(c, "to")
+    if !ok {
+        return
+    }
+    q = q.Preload("Attachments").Order("homeworks.due_date, homeworks.id")
+    if classID := c.Query("class_id"); classID != "" {
+        q = q.Joins("JOIN lesson_logs ON lesson_logs.id = homeworks.lesson_id").
+            Where("lesson_logs.class_id = ?", classID)
+    }
+    if from != "" {
+        q = q.Where("homeworks.due_date >= ?", from)
+    }
+    if to != "" {
+        q = q.Where("homeworks.due_date <= ?", to)
+    }
+    var items []models.Homework
+    if err := q.Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+func (h HomeworkHandler) Create(c *gin.Context) {
+    var input models.Homework
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    input.ID = 0
+    input.Lesson = nil
+    for i := range input.Attachments {
+        input.Attachments[i].ID = 0
+    }
+    if !h.validate(c, input) {
+        return
+    }
+    if err := h.DB.WithContext(c.Request.Context()).Omit("Lesson").Create(&input).Error; err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(http.StatusCreated, input)
+}
+
+func (h HomeworkHandler) Get(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    q, err := withExpand(c, h.DB.WithContext(c.Request.Context()), homeworkExpand)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    var item models.Homework
+    if err := q.Preload("Attachments").First(&item, id).Error; err != nil {
+        if err == gorm.ErrRecordNotFound {
+            errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        } else {
+            respondError(c, err)
+        }
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+// Update replaces the homework, including its attachment list.
+func (h HomeworkHandler) Update(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.Homework
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    var input models.Homework
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    item.LessonID = input.LessonID
+    item.Description = input.Description
+    item.DueDate = input.DueDate
+    if !h.validate(c, item) {
+        return
+    }
+    err := h.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Omit(clause.Associations).Save(&item).Error; err != nil {
+            return err
+        }
+        if err := tx.Where("homework_id = ?", item.ID).Delete(&models.HomeworkAttachment{}).Error; err != nil {
+            return err
+        }
+        item.Attachments = input.Attachments
+        for i := range item.Attachments {
+            item.Attachments[i].ID = 0
+            item.Attachments[i].HomeworkID = item.ID
+        }
+        if len(item.Attachments) == 0 {
+            return nil
+        }
+        return tx.Create(&item.Attachments).Error
+    })
+    if err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+func (h HomeworkHandler) Delete(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    if err := h.DB.WithContext(c.Request.Context()).Delete(&models.Homework{}, id).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.Status(http.StatusNoContent)
+}
+
+// ForStudent returns homework due for the student's class in the week
+// starting ?from= (default today).
+func (h HomeworkHandler) ForStudent(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var student models.Student
+    if err := h.DB.WithContext(c.Request.Context()).First(&student, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    from, ok := queryDate(c, "from")
+    if !ok {
+        return
+    }
+    start, _ := time.Parse(enrollment.DateLayout, from)
+    to := start.AddDate(0, 0, 6).Format(enrollment.DateLayout)
+
+    var items []models.Homework
+    if err := h.DB.WithContext(c.Request.Context()).Joins("JOIN lesson_logs ON lesson_logs.id = homeworks.lesson_id").
+        Where("lesson_logs.class_id = ?", student.ClassID).
+        Where("homeworks.due_date BETWEEN ? AND ?", from, to).
+        Preload("Attachments").Preload("Lesson.Subject").
+        Order("homeworks.due_date, homeworks.id").
+        Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// validate checks that the lesson exists and the due date is a later
+// lesson of the same subject for the same class, either already logged or
+// in the weekly schedule. It writes the error response and returns false
+// on failure.
+func (h HomeworkHandler) validate(c *gin.Context, hw models.Homework) bool {
+    var lesson models.LessonLog
+    if err := h.DB.WithContext(c.Request.Context()).First(&lesson, hw.LessonID).Error; err != nil {
+        errorResponse(c, http.StatusUnprocessableEntity, "UnprocessableEntity", "lesson_id does not exist")
+        return false
+    }
+    due, err := time.Parse(enrollment.DateLayout, hw.DueDate)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "due_date must be in YYYY-MM-DD format")
+        return false
+    }
+    given, err := time.Parse(enrollment.DateLayout, enrollment.DateOnly(lesson.Date))
+    if err != nil || !due.After(given) {
+        errorResponse(c, http.StatusUnprocessableEntity, "UnprocessableEntity", errInvalidDueDate.Error())
+        return false
+    }
+    weekday := int(due.Weekday())
+    if weekday == 0 {
+        weekday = 7
+    }
+    var scheduled, logged int64
+    if err := h.DB.WithContext(c.Request.Context()).Model(&models.LessonSchedule{}).
+        Where("class_id = ? AND subject_id = ? AND weekday = ?", lesson.ClassID, lesson.SubjectID, weekday).
+        Count(&scheduled).Error; err != nil {
+        respondError(c, err)
+        return false
+    }
+    if scheduled == 0 {
+        if err := h.DB.WithContext(c.Request.Context()).Model(&models.LessonLog{}).
+            Where("class_id = ? AND subject_id = ? AND date = ?", lesson.ClassID, lesson.SubjectID, hw.DueDate).
+            Count(&logged).Error; err != nil {
+            respondError(c, err)
+            return false
+        }
+    }
+    if scheduled == 0 && logged == 0 {
+        errorResponse(c, http.StatusUnprocessableEntity, "UnprocessableEntity",
+            fmt.Sprintf("%s: no lesson of this subject on %s", errInvalidDueDate, hw.DueDate))
+        return false
+    }
+    return true
+}
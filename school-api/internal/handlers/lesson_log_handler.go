@@ -3,12 +3,24 @@ package handlers
 import (
     "net/http"
     "strconv"
+
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
+    "school-api/internal/events"
     "school-api/internal/models"
+    "school-api/internal/service"
 )
 
-type LessonLogHandler struct{ DB *gorm.DB }
+type LessonLogHandler struct {
+    DB     *gorm.DB
+    Events *events.Bus
+}
+
+var lessonLogExpand = map[string]string{
+    "subject": "Subject",
+    "class":   "Class",
+    "teacher": "Teacher",
+}
 
 func (h LessonLogHandler) Register(r *gin.RouterGroup) {
     r.GET("/lesson-logs", h.List)
@@ -18,10 +30,19 @@ func (h LessonLogHandler) Register(r *gin.RouterGroup) {
     r.DELETE("/lesson-logs/:id", h.Delete)
 }
 
+func (h LessonLogHandler) service() service.LessonLogs {
+    return service.LessonLogs{DB: h.DB, Events: h.Events}
+}
+
 func (h LessonLogHandler) List(c *gin.Context) {
-    var items []models.LessonLog
-    if err := h.DB.Find(&items).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    preload, err := expandPaths(c, lessonLogExpand)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    items, err := h.service().List(c.Request.Context(), preload...)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, gin.H{"data": items})
@@ -30,26 +51,27 @@ func (h LessonLogHandler) List(c *gin.Context) {
 func (h LessonLogHandler) Create(c *gin.Context) {
     var input models.LessonLog
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    input.ID = 0
-    if err := h.DB.Create(&input).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Create(c.Request.Context(), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
-    c.JSON(http.StatusCreated, input)
+    c.JSON(http.StatusCreated, item)
 }
 
 func (h LessonLogHandler) Get(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.LessonLog
-    if err := h.DB.First(&item, id).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        } else {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        }
+    preload, err := expandPaths(c, lessonLogExpand)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    item, err := h.service().Get(c.Request.Context(), uint(id), preload...)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -57,23 +79,14 @@ func (h LessonLogHandler) Get(c *gin.Context) {
 
 func (h LessonLogHandler) Update(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.LessonLog
-    if err := h.DB.First(&item, id).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        return
-    }
     var input models.LessonLog
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    item.SubjectID = input.SubjectID
-    item.Date = input.Date
-    item.Number = input.Number
-    item.ClassID = input.ClassID
-    item.TeacherID = input.TeacherID
-    if err := h.DB.Save(&item).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Update(c.Request.Context(), uint(id), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -81,11 +94,9 @@ func (h LessonLogHandler) Update(c *gin.Context) {
 
 func (h LessonLogHandler) Delete(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    if err := h.DB.Delete(&models.LessonLog{}, id).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    if err := h.service().Delete(c.Request.Context(), uint(id)); err != nil {
+        respondError(c, err)
         return
     }
     c.Status(http.StatusNoContent)
 }
-
-
@@ -6,10 +6,15 @@ import (
 
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
+    "school-api/internal/events"
     "school-api/internal/models"
+    "school-api/internal/service"
 )
 
-type LessonScheduleHandler struct{ DB *gorm.DB }
+type LessonScheduleHandler struct {
+    DB     *gorm.DB
+    Events *events.Bus
+}
 
 func (h LessonScheduleHandler) Register(r *gin.RouterGroup) {
     r.GET("/lesson-schedules", h.List)
@@ -19,10 +24,14 @@ func (h LessonScheduleHandler) Register(r *gin.RouterGroup) {
     r.DELETE("/lesson-schedules/:id", h.Delete)
 }
 
+func (h LessonScheduleHandler) service() service.LessonSchedules {
+    return service.LessonSchedules{DB: h.DB, Events: h.Events}
+}
+
 func (h LessonScheduleHandler) List(c *gin.Context) {
-    var items []models.LessonSchedule
-    if err := h.DB.Find(&items).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    items, err := h.service().List(c.Request.Context())
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, gin.H{"data": items})
@@ -31,26 +40,22 @@ func (h LessonScheduleHandler) List(c *gin.Context) {
 func (h LessonScheduleHandler) Create(c *gin.Context) {
     var input models.LessonSchedule
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    input.ID = 0
-    if err := h.DB.Create(&input).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Create(c.Request.Context(), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
-    c.JSON(http.StatusCreated, input)
+    c.JSON(http.StatusCreated, item)
 }
 
 func (h LessonScheduleHandler) Get(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.LessonSchedule
-    if err := h.DB.First(&item, id).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        } else {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        }
+    item, err := h.service().Get(c.Request.Context(), uint(id))
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -58,23 +63,14 @@ func (h LessonScheduleHandler) Get(c *gin.Context) {
 
 func (h LessonScheduleHandler) Update(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.LessonSchedule
-    if err := h.DB.First(&item, id).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        return
-    }
     var input models.LessonSchedule
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    item.SubjectID = input.SubjectID
-    item.Weekday = input.Weekday
-    item.Number = input.Number
-    item.ClassID = input.ClassID
-    item.TeacherID = input.TeacherID
-    if err := h.DB.Save(&item).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Update(c.Request.Context(), uint(id), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -82,11 +78,9 @@ func (h LessonScheduleHandler) Update(c *gin.Context) {
 
 func (h LessonScheduleHandler) Delete(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    if err := h.DB.Delete(&models.LessonSchedule{}, id).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    if err := h.service().Delete(c.Request.Context(), uint(id)); err != nil {
+        respondError(c, err)
         return
     }
     c.Status(http.StatusNoContent)
 }
-
-
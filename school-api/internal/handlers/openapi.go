@@ -0,0 +1,180 @@
+package handlers
+
+import (
+    "net/http"
+    "sort"
+    "strings"
+
+    dbpkg "school-api/internal/db"
+    "school-api/internal/models"
+    "school-api/internal/openapi"
+    "school-api/internal/rollover"
+    "school-api/internal/search"
+)
+
+// Query parameters shared by several routes.
+var (
+    dateParam = func(name, desc string) openapi.Param {
+        return openapi.Param{Name: name, Type: "string", Format: "date", Description: desc}
+    }
+    classIDParam = openapi.Param{Name: "class_id", Type: "integer", Description: "Filter by class"}
+    expandParam  = func(allowed map[string]string) openapi.Param {
+        names := make([]string, 0, len(allowed))
+        for name := range allowed {
+            names = append(names, name)
+        }
+        sort.Strings(names)
+        return openapi.Param{Name: "expand", Type: "string",
+            Description: "Comma-separated relations to include: " + strings.Join(names, ", ")}
+    }
+)
+
+// Operations documents the routes registered by the handlers, relative to
+// the API group. Request and response schemas are generated from the types
+// named here; routes missing from the list are still published with their
+// path parameters only.
+func Operations() []openapi.Operation {
+    var ops []openapi.Operation
+    crud := func(path, tag string, model any, itemPath string) {
+        ops = append(ops,
+            openapi.Operation{Method: http.MethodGet, Path: path, Tag: tag, Summary: "List " + tag, Response: model, List: true},
+            openapi.Operation{Method: http.MethodPost, Path: path, Tag: tag, Summary: "Create", Body: model, Response: model, Status: http.StatusCreated},
+            openapi.Operation{Method: http.MethodGet, Path: itemPath, Tag: tag, Summary: "Get by key", Response: model},
+            openapi.Operation{Method: http.MethodPut, Path: itemPath, Tag: tag, Summary: "Replace", Body: model, Response: model},
+            openapi.Operation{Method: http.MethodDelete, Path: itemPath, Tag: tag, Summary: "Delete", Status: http.StatusNoContent},
+        )
+    }
+    crud("/classes", "Classes", models.Class{}, "/classes/:id")
+    crud("/students", "Students", models.Student{}, "/students/:id")
+    crud("/teachers", "Teachers", models.Teacher{}, "/teachers/:id")
+    crud("/subjects", "Subjects", models.Subject{}, "/subjects/:id")
+    crud("/teacher-assignments", "TeacherAssignments", models.TeacherAssignment{}, "/teacher-assignments/:id")
+    crud("/lesson-schedules", "LessonSchedules", models.LessonSchedule{}, "/lesson-schedules/:id")
+    crud("/lesson-logs", "LessonLogs", models.LessonLog{}, "/lesson-logs/:id")
+    crud("/student-lessons", "StudentLessons", models.StudentLesson{}, "/student-lessons/:id")
+    crud("/attendance-statuses", "AttendanceStatuses", models.AttendanceStatus{}, "/attendance-statuses/:code")
+    crud("/guardians", "Guardians", models.Guardian{}, "/guardians/:id")
+    crud("/homework", "Homework", models.Homework{}, "/homework/:id")
+    crud("/webhooks", "Webhooks", models.WebhookSubscription{}, "/webhooks/:id")
+
+    // Query parameters and bodies that differ from plain CRUD.
+    set := func(method, path string, fn func(*openapi.Operation)) {
+        for i := range ops {
+            if ops[i].Method == method && ops[i].Path == path {
+                fn(&ops[i])
+            }
+        }
+    }
+    // Attendance statuses are shared by all schools and changed by operators.
+    for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+        for _, path := range []string{"/attendance-statuses", "/attendance-statuses/:code"} {
+            set(method, path, func(op *openapi.Operation) { op.Auth = true })
+        }
+    }
+    set(http.MethodGet, "/students", func(op *openapi.Operation) {
+        op.Query = []openapi.Param{classIDParam, dateParam("date", "Roster date for class_id, default today")}
+    })
+    for _, path := range []string{"/lesson-logs", "/lesson-logs/:id"} {
+        set(http.MethodGet, path, func(op *openapi.Operation) { op.Query = []openapi.Param{expandParam(lessonLogExpand)} })
+    }
+    for _, path := range []string{"/student-lessons", "/student-lessons/:id"} {
+        set(http.MethodGet, path, func(op *openapi.Operation) { op.Query = []openapi.Param{expandParam(studentLessonExpand)} })
+    }
+    set(http.MethodGet, "/homework", func(op *openapi.Operation) {
+        op.Query = []openapi.Param{expandParam(homeworkExpand), classIDParam,
+            dateParam("from", "Earliest due date"), dateParam("to", "Latest due date")}
+    })
+    set(http.MethodGet, "/homework/:id", func(op *openapi.Operation) { op.Query = []openapi.Param{expandParam(homeworkExpand)} })
+    set(http.MethodPost, "/webhooks", func(op *openapi.Operation) { op.Body = webhookInput{} })
+    set(http.MethodPut, "/webhooks/:id", func(op *openapi.Operation) { op.Body = webhookInput{} })
+
+    limit := func(def string, upper float64) openapi.Param {
+        min, max := openapi.Range(1, upper)
+        return openapi.Param{Name: "limit", Type: "integer", Min: min, Max: max,
+            Description: "Maximum number of results, default " + def}
+    }
+    ops = append(ops,
+        openapi.Operation{Method: http.MethodGet, Path: "/classes/:id/students", Tag: "Classes", Summary: "Class roster on a date",
+            Query: []openapi.Param{dateParam("date", "Default today")}, Response: models.Student{}, List: true},
+        openapi.Operation{Method: http.MethodPost, Path: "/students/:id/transfer", Tag: "Students", Summary: "Transfer to another class",
+            Body: transferInput{}, Response: models.Enrollment{}, Status: http.StatusCreated},
+        openapi.Operation{Method: http.MethodGet, Path: "/students/:id/enrollments", Tag: "Students", Summary: "Class history",
+            Response: models.Enrollment{}, List: true},
+        openapi.Operation{Method: http.MethodGet, Path: "/students/:id/homework", Tag: "Homework", Summary: "Homework due in the week",
+            Query: []openapi.Param{dateParam("from", "First day of the week, default today")}, Response: models.Homework{}, List: true},
+        openapi.Operation{Method: http.MethodGet, Path: "/duplicates", Tag: "Maintenance", Summary: "Rows violating natural keys",
+            Response: dbpkg.DuplicateGroup{}, List: true},
+        openapi.Operation{Method: http.MethodPost, Path: "/rollover/preview", Tag: "Maintenance", Summary: "Preview the year-end rollover",
+            Body: rollover.Options{}, BodyOptional: true, Response: rollover.Summary{}},
+        openapi.Operation{Method: http.MethodPost, Path: "/rollover", Tag: "Maintenance", Summary: "Apply the year-end rollover",
+            Body: rollover.Options{}, BodyOptional: true, Response: rollover.Summary{}, Status: http.StatusCreated},
+        openapi.Operation{Method: http.MethodGet, Path: "/guardians/:id/students", Tag: "Guardians", Summary: "Linked students",
+            Response: models.Student{}, List: true},
+        openapi.Operation{Method: http.MethodPut, Path: "/guardians/:id/students/:student_id", Tag: "Guardians", Summary: "Link a student",
+            Status: http.StatusNoContent},
+        openapi.Operation{Method: http.MethodDelete, Path: "/guardians/:id/students/:student_id", Tag: "Guardians", Summary: "Unlink a student",
+            Status: http.StatusNoContent},
+        openapi.Operation{Method: http.MethodGet, Path: "/homework/:id/submissions", Tag: "Submissions", Summary: "Submissions for a homework",
+            Response: models.HomeworkSubmission{}, List: true},
+        openapi.Operation{Method: http.MethodPost, Path: "/homework/:id/submissions", Tag: "Submissions", Summary: "Submit a file",
+            Form: []openapi.Param{
+                {Name: "student_id", Type: "integer", Required: true},
+                {Name: "file", Type: "file", Required: true},
+            }, Response: models.HomeworkSubmission{}, Status: http.StatusCreated},
+        openapi.Operation{Method: http.MethodGet, Path: "/submissions/:id", Tag: "Submissions", Summary: "Get a submission",
+            Response: models.HomeworkSubmission{}},
+        openapi.Operation{Method: http.MethodGet, Path: "/submissions/:id/file", Tag: "Submissions", Summary: "Download the file",
+            Binary: true},
+        openapi.Operation{Method: http.MethodPut, Path: "/submissions/:id/grade", Tag: "Submissions", Summary: "Grade a submission",
+            Body: gradeInput{}, Response: models.HomeworkSubmission{}},
+        openapi.Operation{Method: http.MethodGet, Path: "/webhooks/:id/deliveries", Tag: "Webhooks", Summary: "Delivery log",
+            Query: []openapi.Param{limit("100", 1000), {Name: "status", Type: "string",
+                Enum: []string{models.DeliveryPending, models.DeliverySucceeded, models.DeliveryFailed}}},
+            Response: models.WebhookDelivery{}, List: true},
+        openapi.Operation{Method: http.MethodGet, Path: "/events/stream", Tag: "Events", Summary: "Server-Sent Events feed of journal changes",
+            Query: []openapi.Param{classIDParam}},
+        openapi.Operation{Method: http.MethodGet, Path: "/search", Tag: "Search", Summary: "Search students, teachers and subjects",
+            Query: []openapi.Param{
+                {Name: "q", Type: "string", Required: true},
+                {Name: "types", Type: "string", Description: "Comma-separated: student, teacher, subject"},
+                limit("20", 100),
+            }, Response: search.Result{}, List: true},
+        openapi.Operation{Method: http.MethodGet, Path: "/graphql", Tag: "GraphQL", Summary: "Execute a GraphQL query",
+            Query: []openapi.Param{
+                {Name: "query", Type: "string", Required: true},
+                {Name: "operationName", Type: "string"},
+                {Name: "variables", Type: "string", Description: "JSON object"},
+            }},
+        openapi.Operation{Method: http.MethodPost, Path: "/graphql", Tag: "GraphQL", Summary: "Execute a GraphQL query",
+            Body: graphQLRequest{}},
+    )
+
+    child := []openapi.Param{dateParam("from", "Earliest lesson date"), dateParam("to", "Latest lesson date")}
+    ops = append(ops,
+        openapi.Operation{Method: http.MethodGet, Path: "/me/children", Tag: "Parent portal", Summary: "Own children",
+            Response: models.Student{}, List: true, Auth: true},
+        openapi.Operation{Method: http.MethodGet, Path: "/me/children/:id/grades", Tag: "Parent portal", Summary: "Child's grades",
+            Query: child, Response: models.StudentLesson{}, List: true, Auth: true},
+        openapi.Operation{Method: http.MethodGet, Path: "/me/children/:id/attendance", Tag: "Parent portal", Summary: "Child's attendance",
+            Query: child, Response: models.StudentLesson{}, List: true, Auth: true},
+        openapi.Operation{Method: http.MethodGet, Path: "/me/children/:id/timetable", Tag: "Parent portal", Summary: "Child's timetable",
+            Response: models.LessonSchedule{}, List: true, Auth: true},
+    )
+
+    ops = append(ops,
+        openapi.Operation{Method: http.MethodGet, Path: "/school", Tag: "Schools", Summary: "The school of the request",
+            Response: models.School{}},
+    )
+
+    ops = append(ops,
+        openapi.Operation{Method: http.MethodGet, Path: "/api-keys", Tag: "API keys", Summary: "List API keys",
+            Response: models.APIKey{}, List: true, Auth: true},
+        openapi.Operation{Method: http.MethodPost, Path: "/api-keys", Tag: "API keys", Summary: "Create an API key; the key is shown only once",
+            Body: apiKeyInput{}, Response: createdAPIKey{}, Status: http.StatusCreated, Auth: true},
+        openapi.Operation{Method: http.MethodGet, Path: "/api-keys/:id", Tag: "API keys", Summary: "Get an API key",
+            Response: models.APIKey{}, Auth: true},
+        openapi.Operation{Method: http.MethodDelete, Path: "/api-keys/:id", Tag: "API keys", Summary: "Revoke an API key",
+            Status: http.StatusNoContent, Auth: true},
+    )
+    return ops
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "school-api/internal/auth"
+    "school-api/internal/models"
+)
+
+// ParentHandler serves the read-only parent portal. Every route is scoped
+// to the children linked to the authenticated guardian.
+type ParentHandler struct{ DB *gorm.DB }
+
+// Register expects r to already require a guardian token.
+func (h ParentHandler) Register(r *gin.RouterGroup) {
+    r.GET("/children", h.Children)
+    r.GET("/children/:id/grades", h.Grades)
+    r.GET("/children/:id/attendance", h.Attendance)
+    r.GET("/children/:id/timetable", h.Timetable)
+}
+
+func (h ParentHandler) children(c *gin.Context) *gorm.DB {
+    p, _ := auth.FromContext(c)
+    return h.DB.WithContext(c.Request.Context()).Model(&models.Student{}).
+        Joins("JOIN guardian_students gs ON gs.student_id = students.id").
+        Where("gs.guardian_id = ?", p.GuardianID)
+}
+
+// child loads one of the caller's children. Students that are not linked
+// to the guardian are reported as not found.
+func (h ParentHandler) child(c *gin.Context) (models.Student, bool) {
+    var item models.Student
+    if err := h.children(c).Where("students.id = ?", c.Param("id")).First(&item).Error; err != nil {
+        if err == gorm.ErrRecordNotFound {
+            errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        } else {
+            respondError(c, err)
+        }
+        return item, false
+    }
+    return item, true
+}
+
+func (h ParentHandler) Children(c *gin.Context) {
+    var items []models.Student
+    if err := h.children(c).Order("students.last_name, students.first_name").Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// lessons returns the child's journal entries, optionally limited to
+// ?from= / ?to= lesson dates (inclusive).
+func (h ParentHandler) lessons(c *gin.Context, student models.Student) (*gorm.DB, bool) {
+    from, ok := optionalQueryDate(c, "from")
+    if !ok {
+        return nil, false
+    }
+    to, ok := optionalQueryDate(c, "to")
+    if !ok {
+        return nil, false
+    }
+    q := h.DB.WithContext(c.Request.Context()).Model(&models.StudentLesson{}).
+        Joins("JOIN lesson_logs ON lesson_logs.id = student_lessons.lesson_id").
+        Where("student_lessons.student_id = ?", student.ID).
+        Preload("Lesson.Subject").Preload("Lesson.Teacher").
+        Order("lesson_logs.date DESC, lesson_logs.number")
+    if from != "" {
+        q = q.Where("lesson_logs.date >= ?", from)
+    }
+    if to != "" {
+        q = q.Where("lesson_logs.date <= ?", to)
+    }
+    return q, true
+}
+
+func (h ParentHandler) Grades(c *gin.Context) {
+    student, ok := h.child(c)
+    if !ok {
+        return
+    }
+    q, ok := h.lessons(c, student)
+    if !ok {
+        return
+    }
+    var items []models.StudentLesson
+    if err := q.Where("student_lessons.grade IS NOT NULL").Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+func (h ParentHandler) Attendance(c *gin.Context) {
+    student, ok := h.child(c)
+    if !ok {
+        return
+    }
+    q, ok := h.lessons(c, student)
+    if !ok {
+        return
+    }
+    var items []models.StudentLesson
+    if err := q.Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// Timetable returns the weekly schedule of the child's current class.
+func (h ParentHandler) Timetable(c *gin.Context) {
+    student, ok := h.child(c)
+    if !ok {
+        return
+    }
+    var items []models.LessonSchedule
+    if err := h.DB.WithContext(c.Request.Context()).Where("class_id = ?", student.ClassID).
+        Preload("Subject").Preload("Teacher").
+        Order("weekday, number").Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
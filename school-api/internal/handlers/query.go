@@ -0,0 +1,32 @@
+package handlers
+
+import (
+    "net/http"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "school-api/internal/enrollment"
+)
+
+// queryDate reads a YYYY-MM-DD query parameter, defaulting to today.
+// On a malformed value it writes 400 and returns false.
+func queryDate(c *gin.Context, name string) (string, bool) {
+    raw := c.Query(name)
+    if raw == "" {
+        return enrollment.Today(), true
+    }
+    if _, err := time.Parse(enrollment.DateLayout, raw); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", name + " must be in YYYY-MM-DD format")
+        return "", false
+    }
+    return raw, true
+}
+
+// optionalQueryDate reads a YYYY-MM-DD query parameter that may be absent
+// (returned as ""). On a malformed value it writes 400 and returns false.
+func optionalQueryDate(c *gin.Context, name string) (string, bool) {
+    if c.Query(name) == "" {
+        return "", true
+    }
+    return queryDate(c, name)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+    "errors"
+    "io"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "school-api/internal/cache"
+    "school-api/internal/rollover"
+    "school-api/internal/service"
+)
+
+type RolloverHandler struct {
+    DB    *gorm.DB
+    Cache *cache.Cache // optional
+}
+
+func (h RolloverHandler) Register(r *gin.RouterGroup) {
+    r.POST("/rollover/preview", h.Preview)
+    r.POST("/rollover", h.Apply)
+}
+
+// Preview returns what the year-end rollover would change without saving it.
+func (h RolloverHandler) Preview(c *gin.Context) {
+    h.run(c, rollover.Preview, http.StatusOK)
+}
+
+// Apply promotes all classes, graduates the final grade and optionally
+// creates new first-grade classes in one transaction.
+func (h RolloverHandler) Apply(c *gin.Context) {
+    h.run(c, func(db *gorm.DB, opts rollover.Options) (rollover.Summary, error) {
+        summary, err := rollover.Apply(db, opts)
+        if err == nil {
+            h.Cache.Invalidate(c.Request.Context(), cache.Classes)
+        }
+        return summary, err
+    }, http.StatusCreated)
+}
+
+func (h RolloverHandler) run(c *gin.Context, fn func(*gorm.DB, rollover.Options) (rollover.Summary, error), status int) {
+    var opts rollover.Options
+    if err := c.ShouldBindJSON(&opts); err != nil && !errors.Is(err, io.EOF) {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    summary, err := fn(h.DB.WithContext(c.Request.Context()), opts)
+    switch {
+    case errors.Is(err, rollover.ErrInvalidOptions):
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    case errors.Is(err, rollover.ErrAlreadyApplied):
+        errorResponse(c, http.StatusConflict, "Conflict", err.Error())
+        return
+    case err != nil:
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(status, summary)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "school-api/internal/models"
+    "school-api/internal/tenant"
+)
+
+// SchoolHandler describes the school a request was resolved to.
+type SchoolHandler struct{ DB *gorm.DB }
+
+func (h SchoolHandler) Register(r *gin.RouterGroup) {
+    r.GET("/school", h.Get)
+}
+
+func (h SchoolHandler) Get(c *gin.Context) {
+    id, _ := tenant.FromContext(c.Request.Context())
+    var item models.School
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        if err == gorm.ErrRecordNotFound {
+            errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        } else {
+            respondError(c, err)
+        }
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+    "net/http"
+    "slices"
+    "strconv"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "school-api/internal/search"
+)
+
+type SearchHandler struct{ DB *gorm.DB }
+
+func (h SearchHandler) Register(r *gin.RouterGroup) {
+    r.GET("/search", h.Search)
+}
+
+// Search finds students, teachers and subjects matching ?q=, including
+// typos and "Иванов И" style surname + initials queries. ?types= limits the
+// result types (comma-separated), ?limit= caps the result count.
+func (h SearchHandler) Search(c *gin.Context) {
+    q := strings.TrimSpace(c.Query("q"))
+    if q == "" {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "q is required")
+        return
+    }
+    limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+    if err != nil || limit < 1 || limit > 100 {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "limit must be between 1 and 100")
+        return
+    }
+    types := search.Types
+    if raw := c.Query("types"); raw != "" {
+        types = strings.Split(raw, ",")
+        for _, t := range types {
+            if !slices.Contains(search.Types, t) {
+                errorResponse(c, http.StatusBadRequest, "BadRequest", "unknown type " + strconv.Quote(t))
+                return
+            }
+        }
+    }
+    items, err := search.Search(h.DB.WithContext(c.Request.Context()), q, types, limit)
+    if err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+    "io"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gin-contrib/sse"
+    "github.com/gin-gonic/gin"
+    "school-api/internal/stream"
+    "school-api/internal/tenant"
+)
+
+// streamHeartbeat keeps idle connections open through proxies.
+const streamHeartbeat = 15 * time.Second
+
+type StreamHandler struct{ Hub *stream.Hub }
+
+func (h StreamHandler) Register(r *gin.RouterGroup) {
+    r.GET("/events/stream", h.Stream)
+}
+
+// Stream is a Server-Sent Events feed of the school's student lesson and
+// lesson log changes, optionally limited to ?class_id=. Each event is named after its
+// type (e.g. "student_lesson.updated") and carries the Message as data.
+// The stream ends when the client leaves or the server shuts down.
+func (h StreamHandler) Stream(c *gin.Context) {
+    var classID uint64
+    if raw := c.Query("class_id"); raw != "" {
+        var err error
+        if classID, err = strconv.ParseUint(raw, 10, 64); err != nil {
+            errorResponse(c, http.StatusBadRequest, "BadRequest", "class_id must be an integer")
+            return
+        }
+    }
+    schoolID, _ := tenant.FromContext(c.Request.Context())
+    msgs, cancel := h.Hub.Subscribe(schoolID, uint(classID))
+    defer cancel()
+
+    // The server's write timeout would cut the stream; heartbeats detect
+    // dead clients instead.
+    http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+    c.Header("Content-Type", "text/event-stream")
+    c.Header("Cache-Control", "no-cache")
+    c.Header("Connection", "keep-alive")
+    c.Header("X-Accel-Buffering", "no")
+    c.Status(http.StatusOK)
+    c.SSEvent("ready", gin.H{"class_id": classID})
+    c.Writer.Flush()
+
+    heartbeat := time.NewTicker(streamHeartbeat)
+    defer heartbeat.Stop()
+    c.Stream(func(w io.Writer) bool {
+        select {
+        case <-c.Request.Context().Done():
+            return false
+        case <-h.Hub.Done():
+            return false
+        case m := <-msgs:
+            c.Render(-1, sse.Event{Id: m.ID, Event: m.Type, Data: m})
+        case <-heartbeat.C:
+            io.WriteString(w, ": ping\n\n")
+        }
+        return true
+    })
+}
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+    "errors"
     "net/http"
     "strconv"
+    "time"
 
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
+    "school-api/internal/enrollment"
     "school-api/internal/models"
+    "school-api/internal/service"
 )
 
 type StudentHandler struct{ DB *gorm.DB }
@@ -17,12 +21,34 @@ func (h StudentHandler) Register(r *gin.RouterGroup) {
     r.GET("/students/:id", h.Get)
     r.PUT("/students/:id", h.Update)
     r.DELETE("/students/:id", h.Delete)
+    r.POST("/students/:id/transfer", h.Transfer)
+    r.GET("/students/:id/enrollments", h.Enrollments)
 }
 
+func (h StudentHandler) service() service.Students {
+    return service.Students{DB: h.DB}
+}
+
+// List returns all students. With ?class_id= it returns the class roster,
+// as of ?date= (YYYY-MM-DD, default today) according to enrollment history.
 func (h StudentHandler) List(c *gin.Context) {
-    var items []models.Student
-    if err := h.DB.Find(&items).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    var filter service.StudentFilter
+    if raw := c.Query("class_id"); raw != "" {
+        classID, err := strconv.ParseUint(raw, 10, 64)
+        if err != nil {
+            errorResponse(c, http.StatusBadRequest, "BadRequest", "class_id must be an integer")
+            return
+        }
+        date, ok := queryDate(c, "date")
+        if !ok {
+            return
+        }
+        id := uint(classID)
+        filter = service.StudentFilter{ClassID: &id, Date: date}
+    }
+    items, err := h.service().List(c.Request.Context(), filter)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, gin.H{"data": items})
@@ -31,49 +57,39 @@ func (h StudentHandler) List(c *gin.Context) {
 func (h StudentHandler) Create(c *gin.Context) {
     var input models.Student
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    input.ID = 0
-    if err := h.DB.Create(&input).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Create(c.Request.Context(), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
-    c.JSON(http.StatusCreated, input)
+    c.JSON(http.StatusCreated, item)
 }
 
 func (h StudentHandler) Get(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.Student
-    if err := h.DB.First(&item, id).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        } else {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        }
+    item, err := h.service().Get(c.Request.Context(), uint(id))
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
 }
 
+// Update changes a student's details. A changed class_id is recorded as a
+// transfer effective today; use POST /students/:id/transfer to backdate it.
 func (h StudentHandler) Update(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.Student
-    if err := h.DB.First(&item, id).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        return
-    }
     var input models.Student
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    item.ClassID = input.ClassID
-    item.FirstName = input.FirstName
-    item.LastName = input.LastName
-    item.Patronymic = input.Patronymic
-    if err := h.DB.Save(&item).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Update(c.Request.Context(), uint(id), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -81,11 +97,63 @@ func (h StudentHandler) Update(c *gin.Context) {
 
 func (h StudentHandler) Delete(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    if err := h.DB.Delete(&models.Student{}, id).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    if err := h.service().Delete(c.Request.Context(), uint(id)); err != nil {
+        respondError(c, err)
         return
     }
     c.Status(http.StatusNoContent)
 }
 
+type transferInput struct {
+    ClassID uint   `json:"class_id" binding:"required"`
+    Date    string `json:"date"`
+    Reason  string `json:"reason"`
+}
 
+// Transfer closes the student's current enrollment and opens a new one in
+// another class, effective on the given date (default today).
+func (h StudentHandler) Transfer(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.Student
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    var input transferInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    if input.Date == "" {
+        input.Date = enrollment.Today()
+    } else if _, err := time.Parse(enrollment.DateLayout, input.Date); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "date must be in YYYY-MM-DD format")
+        return
+    }
+    var next models.Enrollment
+    err := h.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+        var err error
+        next, err = enrollment.Transfer(tx, &item, input.ClassID, input.Date, input.Reason)
+        return err
+    })
+    if errors.Is(err, enrollment.ErrSameClass) || errors.Is(err, enrollment.ErrBeforeCurrent) {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    if err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(http.StatusCreated, next)
+}
+
+// Enrollments returns the student's class history, oldest first.
+func (h StudentHandler) Enrollments(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var items []models.Enrollment
+    if err := h.DB.WithContext(c.Request.Context()).Where("student_id = ?", id).Order("start_date NULLS FIRST, id").Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
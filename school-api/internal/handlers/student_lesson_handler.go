@@ -6,10 +6,23 @@ import (
 
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
+    "school-api/internal/events"
     "school-api/internal/models"
+    "school-api/internal/service"
 )
 
-type StudentLessonHandler struct{ DB *gorm.DB }
+type StudentLessonHandler struct {
+    DB     *gorm.DB
+    Events *events.Bus
+}
+
+var studentLessonExpand = map[string]string{
+    "student":        "Student",
+    "lesson":         "Lesson",
+    "lesson.subject": "Lesson.Subject",
+    "lesson.class":   "Lesson.Class",
+    "lesson.teacher": "Lesson.Teacher",
+}
 
 func (h StudentLessonHandler) Register(r *gin.RouterGroup) {
     r.GET("/student-lessons", h.List)
@@ -19,10 +32,19 @@ func (h StudentLessonHandler) Register(r *gin.RouterGroup) {
     r.DELETE("/student-lessons/:id", h.Delete)
 }
 
+func (h StudentLessonHandler) service() service.StudentLessons {
+    return service.StudentLessons{DB: h.DB, Events: h.Events}
+}
+
 func (h StudentLessonHandler) List(c *gin.Context) {
-    var items []models.StudentLesson
-    if err := h.DB.Find(&items).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    preload, err := expandPaths(c, studentLessonExpand)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    items, err := h.service().List(c.Request.Context(), preload...)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, gin.H{"data": items})
@@ -31,26 +53,27 @@ func (h StudentLessonHandler) List(c *gin.Context) {
 func (h StudentLessonHandler) Create(c *gin.Context) {
     var input models.StudentLesson
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    input.ID = 0
-    if err := h.DB.Create(&input).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Create(c.Request.Context(), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
-    c.JSON(http.StatusCreated, input)
+    c.JSON(http.StatusCreated, item)
 }
 
 func (h StudentLessonHandler) Get(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.StudentLesson
-    if err := h.DB.First(&item, id).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        } else {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        }
+    preload, err := expandPaths(c, studentLessonExpand)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    item, err := h.service().Get(c.Request.Context(), uint(id), preload...)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -58,22 +81,14 @@ func (h StudentLessonHandler) Get(c *gin.Context) {
 
 func (h StudentLessonHandler) Update(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.StudentLesson
-    if err := h.DB.First(&item, id).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        return
-    }
     var input models.StudentLesson
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    item.StudentID = input.StudentID
-    item.LessonID = input.LessonID
-    item.Grade = input.Grade
-    item.AttendanceStatus = input.AttendanceStatus
-    if err := h.DB.Save(&item).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Update(c.Request.Context(), uint(id), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -81,11 +96,9 @@ func (h StudentLessonHandler) Update(c *gin.Context) {
 
 func (h StudentLessonHandler) Delete(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    if err := h.DB.Delete(&models.StudentLesson{}, id).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    if err := h.service().Delete(c.Request.Context(), uint(id)); err != nil {
+        respondError(c, err)
         return
     }
     c.Status(http.StatusNoContent)
 }
-
-
@@ -6,10 +6,15 @@ import (
 
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
+    "school-api/internal/cache"
     "school-api/internal/models"
+    "school-api/internal/service"
 )
 
-type SubjectHandler struct{ DB *gorm.DB }
+type SubjectHandler struct {
+    DB    *gorm.DB
+    Cache *cache.Cache // optional
+}
 
 func (h SubjectHandler) Register(r *gin.RouterGroup) {
     r.GET("/subjects", h.List)
@@ -19,70 +24,64 @@ func (h SubjectHandler) Register(r *gin.RouterGroup) {
     r.DELETE("/subjects/:id", h.Delete)
 }
 
+func (h SubjectHandler) service() service.Subjects {
+    return service.Subjects{DB: h.DB}
+}
+
 func (h SubjectHandler) List(c *gin.Context) {
-    var items []models.Subject
-    if err := h.DB.Find(&items).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        return
-    }
-    c.JSON(http.StatusOK, gin.H{"data": items})
+    ctx := c.Request.Context()
+    cachedJSON(c, h.Cache, cache.ListKey(ctx, cache.Subjects), func() (any, error) {
+        items, err := h.service().List(ctx)
+        return gin.H{"data": items}, err
+    })
 }
 
 func (h SubjectHandler) Create(c *gin.Context) {
     var input models.Subject
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    input.ID = 0
-    if err := h.DB.Create(&input).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Create(c.Request.Context(), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
-    c.JSON(http.StatusCreated, input)
+    writeThrough(c, h.Cache, cache.Subjects, strconv.Itoa(int(item.ID)), item)
+    c.JSON(http.StatusCreated, item)
 }
 
 func (h SubjectHandler) Get(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.Subject
-    if err := h.DB.First(&item, id).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        } else {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        }
-        return
-    }
-    c.JSON(http.StatusOK, item)
+    ctx := c.Request.Context()
+    cachedJSON(c, h.Cache, cache.ItemKey(ctx, cache.Subjects, strconv.Itoa(id)), func() (any, error) {
+        item, err := h.service().Get(ctx, uint(id))
+        return item, err
+    })
 }
 
 func (h SubjectHandler) Update(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.Subject
-    if err := h.DB.First(&item, id).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        return
-    }
     var input models.Subject
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    item.SubjectName = input.SubjectName
-    if err := h.DB.Save(&item).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Update(c.Request.Context(), uint(id), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
+    writeThrough(c, h.Cache, cache.Subjects, strconv.Itoa(int(item.ID)), item)
     c.JSON(http.StatusOK, item)
 }
 
 func (h SubjectHandler) Delete(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    if err := h.DB.Delete(&models.Subject{}, id).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    if err := h.service().Delete(c.Request.Context(), uint(id)); err != nil {
+        respondError(c, err)
         return
     }
+    h.Cache.Invalidate(c.Request.Context(), cache.Subjects)
     c.Status(http.StatusNoContent)
 }
-
-
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "log/slog"
+    "net/http"
+    "path"
+    "strconv"
+    "time"
+
+    "github.com/gabriel-vasile/mimetype"
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "school-api/internal/enrollment"
+    "school-api/internal/events"
+    "school-api/internal/models"
+    "school-api/internal/service"
+    "school-api/internal/storage"
+)
+
+// SubmissionHandler accepts homework files from students and grades from
+// teachers. Files live in Store; rows keep only the key and metadata.
+type SubmissionHandler struct {
+    DB     *gorm.DB
+    Store  storage.Store
+    Limits storage.Limits
+    Events *events.Bus
+}
+
+// defaultAttendance is recorded when grading creates a journal entry for
+// a student that has none for the due lesson yet.
+const defaultAttendance = "P"
+
+func (h SubmissionHandler) Register(r *gin.RouterGroup) {
+    r.GET("/homework/:id/submissions", h.List)
+    r.POST("/homework/:id/submissions", h.Submit)
+    r.GET("/submissions/:id", h.Get)
+    r.GET("/submissions/:id/file", h.Download)
+    r.PUT("/submissions/:id/grade", h.Grade)
+}
+
+func (h SubmissionHandler) List(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var items []models.HomeworkSubmission
+    if err := h.DB.WithContext(c.Request.Context()).Where("homework_id = ?", id).Order("submitted_at").Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// Submit accepts multipart/form-data with fields student_id and file.
+// A later submission by the same student replaces the earlier one.
+func (h SubmissionHandler) Submit(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var hw models.Homework
+    if err := h.DB.WithContext(c.Request.Context()).Preload("Lesson").First(&hw, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    // Limit the body before anything reads it: PostForm parses the whole
+    // multipart form, file included. Up to 32 MiB of it is kept in memory,
+    // gin's default.
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.Limits.MaxBytes+1<<20)
+    if err := c.Request.ParseMultipartForm(32 << 20); err != nil {
+        var tooLarge *http.MaxBytesError
+        if errors.As(err, &tooLarge) {
+            errorResponse(c, http.StatusRequestEntityTooLarge, "RequestEntityTooLarge", fmt.Sprintf("file exceeds %d bytes", h.Limits.MaxBytes))
+            return
+        }
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "multipart/form-data body is required")
+        return
+    }
+    studentID, err := strconv.ParseUint(c.PostForm("student_id"), 10, 64)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "student_id is required")
+        return
+    }
+    var student models.Student
+    if err := h.DB.WithContext(c.Request.Context()).First(&student, studentID).Error; err != nil {
+        errorResponse(c, http.StatusUnprocessableEntity, "UnprocessableEntity", "student_id does not exist")
+        return
+    }
+    if hw.Lesson != nil && student.ClassID != hw.Lesson.ClassID {
+        errorResponse(c, http.StatusUnprocessableEntity, "UnprocessableEntity", "homework is not assigned to the student's class")
+        return
+    }
+
+    fh, err := c.FormFile("file")
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "file is required")
+        return
+    }
+    if fh.Size > h.Limits.MaxBytes {
+        errorResponse(c, http.StatusRequestEntityTooLarge, "RequestEntityTooLarge", fmt.Sprintf("file exceeds %d bytes", h.Limits.MaxBytes))
+        return
+    }
+    f, err := fh.Open()
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    defer f.Close()
+
+    // Trust the content, not the client-supplied header.
+    mt, err := mimetype.DetectReader(f)
+    if err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    if !h.Limits.Allows(mt.String()) {
+        errorResponse(c, http.StatusUnsupportedMediaType, "UnsupportedMediaType", "file type " + mt.String() + " is not allowed")
+        return
+    }
+    if _, err := f.Seek(0, io.SeekStart); err != nil {
+        respondError(c, err)
+        return
+    }
+
+    var item models.HomeworkSubmission
+    err = h.DB.WithContext(c.Request.Context()).Where("homework_id = ? AND student_id = ?", hw.ID, student.ID).First(&item).Error
+    if err != nil && err != gorm.ErrRecordNotFound {
+        respondError(c, err)
+        return
+    }
+    // The grade was given for the file the teacher saw.
+    if item.GradedAt != nil {
+        errorResponse(c, http.StatusConflict, "Conflict", "submission has already been graded")
+        return
+    }
+
+    now := time.Now()
+    key := fmt.Sprintf("homework/%d/%d/%d%s", hw.ID, student.ID, now.UnixNano(), mt.Extension())
+    if err := h.Store.Put(c.Request.Context(), key, f, fh.Size, mt.String()); err != nil {
+        slog.ErrorContext(c.Request.Context(), "storing submission file failed", "key", key, "error", err)
+        errorResponse(c, http.StatusInternalServerError, "InternalServerError", "failed to store file")
+        return
+    }
+    oldKey := item.FileKey
+    status := http.StatusOK
+    if item.ID == 0 {
+        status = http.StatusCreated
+    }
+    item.HomeworkID = hw.ID
+    item.StudentID = student.ID
+    item.FileKey = key
+    item.FileName = path.Base(fh.Filename)
+    item.ContentType = mt.String()
+    item.Size = fh.Size
+    item.SubmittedAt = now
+    item.Late = isLate(now, hw.DueDate)
+    if err := h.DB.WithContext(c.Request.Context()).Save(&item).Error; err != nil {
+        h.Store.Delete(c.Request.Context(), key)
+        respondError(c, service.Invalid(err))
+        return
+    }
+    if oldKey != "" {
+        h.Store.Delete(c.Request.Context(), oldKey)
+    }
+    c.JSON(status, item)
+}
+
+func (h SubmissionHandler) Get(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.HomeworkSubmission
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        if err == gorm.ErrRecordNotFound {
+            errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        } else {
+            respondError(c, err)
+        }
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+func (h SubmissionHandler) Download(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.HomeworkSubmission
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    rc, err := h.Store.Get(c.Request.Context(), item.FileKey)
+    if errors.Is(err, storage.ErrNotFound) {
+        errorResponse(c, http.StatusNotFound, "NotFound", "File not found")
+        return
+    }
+    if err != nil {
+        slog.ErrorContext(c.Request.Context(), "reading submission file failed", "key", item.FileKey, "error", err)
+        errorResponse(c, http.StatusInternalServerError, "InternalServerError", "failed to read file")
+        return
+    }
+    defer rc.Close()
+    c.DataFromReader(http.StatusOK, item.Size, item.ContentType, rc, map[string]string{
+        "Content-Disposition": fmt.Sprintf("attachment; filename=%q", item.FileName),
+    })
+}
+
+type gradeInput struct {
+    Grade   *int   `json:"grade" binding:"required,min=1,max=5"`
+    Comment string `json:"comment"`
+}
+
+// Grade records the teacher's grade on the submission and copies it into
+// the student's journal entry for the lesson the homework was due at.
+func (h SubmissionHandler) Grade(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.HomeworkSubmission
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    var input gradeInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    var hw models.Homework
+    if err := h.DB.WithContext(c.Request.Context()).Preload("Lesson").First(&hw, item.HomeworkID).Error; err != nil || hw.Lesson == nil {
+        errorResponse(c, http.StatusUnprocessableEntity, "UnprocessableEntity", "homework lesson not found")
+        return
+    }
+    var due models.LessonLog
+    err := h.DB.WithContext(c.Request.Context()).Where("class_id = ? AND subject_id = ? AND date = ?", hw.Lesson.ClassID, hw.Lesson.SubjectID, enrollment.DateOnly(hw.DueDate)).
+        Order("number").First(&due).Error
+    if err == gorm.ErrRecordNotFound {
+        errorResponse(c, http.StatusUnprocessableEntity, "UnprocessableEntity", "the lesson the homework is due at has not been logged yet")
+        return
+    }
+    if err != nil {
+        respondError(c, err)
+        return
+    }
+
+    now := time.Now()
+    item.Grade = input.Grade
+    item.Comment = input.Comment
+    item.GradedAt = &now
+    var entry models.StudentLesson
+    var before *models.StudentLesson
+    err = h.DB.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+        if err := tx.Save(&item).Error; err != nil {
+            return err
+        }
+        err := tx.Where("student_id = ? AND lesson_id = ?", item.StudentID, due.ID).First(&entry).Error
+        if err == gorm.ErrRecordNotFound {
+            entry = models.StudentLesson{StudentID: item.StudentID, LessonID: due.ID, AttendanceStatus: defaultAttendance}
+        } else if err != nil {
+            return err
+        } else {
+            prev := entry
+            before = &prev
+        }
+        entry.Grade = input.Grade
+        return tx.Omit("Student", "Lesson").Save(&entry).Error
+    })
+    if err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    journal := service.StudentLessons{DB: h.DB, Events: h.Events}
+    if before == nil {
+        journal.Publish(c.Request.Context(), events.StudentLessonCreated, entry, nil)
+    } else {
+        journal.Publish(c.Request.Context(), events.StudentLessonUpdated, entry, before)
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+// isLate reports whether a submission at t comes after the due date
+// (submissions during the due day are on time).
+func isLate(t time.Time, dueDate string) bool {
+    due, err := time.ParseInLocation(enrollment.DateLayout, enrollment.DateOnly(dueDate), t.Location())
+    if err != nil {
+        return false
+    }
+    return !t.Before(due.AddDate(0, 0, 1))
+}
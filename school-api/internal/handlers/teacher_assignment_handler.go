@@ -7,6 +7,7 @@ import (
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
     "school-api/internal/models"
+    "school-api/internal/service"
 )
 
 type TeacherAssignmentHandler struct{ DB *gorm.DB }
@@ -19,10 +20,14 @@ func (h TeacherAssignmentHandler) Register(r *gin.RouterGroup) {
     r.DELETE("/teacher-assignments/:id", h.Delete)
 }
 
+func (h TeacherAssignmentHandler) service() service.TeacherAssignments {
+    return service.TeacherAssignments{DB: h.DB}
+}
+
 func (h TeacherAssignmentHandler) List(c *gin.Context) {
-    var items []models.TeacherAssignment
-    if err := h.DB.Find(&items).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    items, err := h.service().List(c.Request.Context())
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, gin.H{"data": items})
@@ -31,26 +36,22 @@ func (h TeacherAssignmentHandler) List(c *gin.Context) {
 func (h TeacherAssignmentHandler) Create(c *gin.Context) {
     var input models.TeacherAssignment
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    input.ID = 0
-    if err := h.DB.Create(&input).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Create(c.Request.Context(), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
-    c.JSON(http.StatusCreated, input)
+    c.JSON(http.StatusCreated, item)
 }
 
 func (h TeacherAssignmentHandler) Get(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.TeacherAssignment
-    if err := h.DB.First(&item, id).Error; err != nil {
-        if err == gorm.ErrRecordNotFound {
-            c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        } else {
-            c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
-        }
+    item, err := h.service().Get(c.Request.Context(), uint(id))
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -58,20 +59,14 @@ func (h TeacherAssignmentHandler) Get(c *gin.Context) {
 
 func (h TeacherAssignmentHandler) Update(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    var item models.TeacherAssignment
-    if err := h.DB.First(&item, id).Error; err != nil {
-        c.JSON(http.StatusNotFound, gin.H{"error": "NotFound", "message": "Resource not found"})
-        return
-    }
     var input models.TeacherAssignment
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": err.Error()})
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
         return
     }
-    item.TeacherID = input.TeacherID
-    item.SubjectID = input.SubjectID
-    if err := h.DB.Save(&item).Error; err != nil {
-        c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "UnprocessableEntity", "message": err.Error()})
+    item, err := h.service().Update(c.Request.Context(), uint(id), input)
+    if err != nil {
+        respondError(c, err)
         return
     }
     c.JSON(http.StatusOK, item)
@@ -79,11 +74,9 @@ func (h TeacherAssignmentHandler) Update(c *gin.Context) {
 
 func (h TeacherAssignmentHandler) Delete(c *gin.Context) {
     id, _ := strconv.Atoi(c.Param("id"))
-    if err := h.DB.Delete(&models.TeacherAssignment{}, id).Error; err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": "InternalServerError", "message": err.Error()})
+    if err := h.service().Delete(c.Request.Context(), uint(id)); err != nil {
+        respondError(c, err)
         return
     }
     c.Status(http.StatusNoContent)
 }
-
-
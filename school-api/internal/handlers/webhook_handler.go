@@ -0,0 +1,167 @@
+package handlers
+
+import (
+    "fmt"
+    "net/http"
+    "net/netip"
+    "net/url"
+    "slices"
+    "strconv"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+    "school-api/internal/events"
+    "school-api/internal/models"
+    "school-api/internal/service"
+    "school-api/internal/webhooks"
+)
+
+type WebhookHandler struct {
+    DB *gorm.DB
+    // AllowedNetworks may receive deliveries besides public addresses.
+    AllowedNetworks []netip.Prefix
+}
+
+func (h WebhookHandler) Register(r *gin.RouterGroup) {
+    r.GET("/webhooks", h.List)
+    r.POST("/webhooks", h.Create)
+    r.GET("/webhooks/:id", h.Get)
+    r.PUT("/webhooks/:id", h.Update)
+    r.DELETE("/webhooks/:id", h.Delete)
+    r.GET("/webhooks/:id/deliveries", h.Deliveries)
+}
+
+// webhookInput is the writable part of a subscription. The secret is
+// accepted on write but never returned.
+type webhookInput struct {
+    URL        string   `json:"url" binding:"required"`
+    EventTypes []string `json:"event_types" binding:"required,min=1"`
+    Secret     string   `json:"secret" binding:"required,min=16"`
+    Active     *bool    `json:"active"`
+}
+
+// validate rejects URLs naming an internal address outright. Host names are
+// checked by the dispatcher each time it connects (webhooks.NewClient).
+func (in webhookInput) validate(allow []netip.Prefix) error {
+    u, err := url.Parse(in.URL)
+    if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+        return fmt.Errorf("url must be an absolute http(s) URL")
+    }
+    host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+    if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+        host = "127.0.0.1"
+    }
+    if ip, err := netip.ParseAddr(host); err == nil && !webhooks.Allowed(ip, allow) {
+        return fmt.Errorf("url must not point to a private or local address")
+    }
+    for _, t := range in.EventTypes {
+        if t != "*" && !slices.Contains(events.Types, t) {
+            return fmt.Errorf("unknown event type %q", t)
+        }
+    }
+    return nil
+}
+
+func (in webhookInput) apply(item *models.WebhookSubscription) {
+    item.URL = in.URL
+    item.EventTypes = strings.Join(in.EventTypes, ",")
+    item.Secret = in.Secret
+    item.Active = in.Active == nil || *in.Active
+}
+
+func (h WebhookHandler) List(c *gin.Context) {
+    var items []models.WebhookSubscription
+    if err := h.DB.WithContext(c.Request.Context()).Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+func (h WebhookHandler) Create(c *gin.Context) {
+    var input webhookInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    if err := input.validate(h.AllowedNetworks); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    var item models.WebhookSubscription
+    input.apply(&item)
+    if err := h.DB.WithContext(c.Request.Context()).Create(&item).Error; err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(http.StatusCreated, item)
+}
+
+func (h WebhookHandler) Get(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.WebhookSubscription
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        if err == gorm.ErrRecordNotFound {
+            errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        } else {
+            respondError(c, err)
+        }
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+func (h WebhookHandler) Update(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    var item models.WebhookSubscription
+    if err := h.DB.WithContext(c.Request.Context()).First(&item, id).Error; err != nil {
+        errorResponse(c, http.StatusNotFound, "NotFound", "Resource not found")
+        return
+    }
+    var input webhookInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    if err := input.validate(h.AllowedNetworks); err != nil {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", err.Error())
+        return
+    }
+    input.apply(&item)
+    if err := h.DB.WithContext(c.Request.Context()).Save(&item).Error; err != nil {
+        respondError(c, service.Invalid(err))
+        return
+    }
+    c.JSON(http.StatusOK, item)
+}
+
+func (h WebhookHandler) Delete(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    if err := h.DB.WithContext(c.Request.Context()).Delete(&models.WebhookSubscription{}, id).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.Status(http.StatusNoContent)
+}
+
+// Deliveries returns the delivery log of a subscription, newest first,
+// optionally filtered by ?status=pending|succeeded|failed.
+func (h WebhookHandler) Deliveries(c *gin.Context) {
+    id, _ := strconv.Atoi(c.Param("id"))
+    limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+    if err != nil || limit < 1 || limit > 1000 {
+        errorResponse(c, http.StatusBadRequest, "BadRequest", "limit must be between 1 and 1000")
+        return
+    }
+    q := h.DB.WithContext(c.Request.Context()).Where("subscription_id = ?", id)
+    if status := c.Query("status"); status != "" {
+        q = q.Where("status = ?", status)
+    }
+    var items []models.WebhookDelivery
+    if err := q.Order("id DESC").Limit(limit).Find(&items).Error; err != nil {
+        respondError(c, err)
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"data": items})
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// SlowQuery is the duration above which statements are logged at warn.
+const SlowQuery = 200 * time.Millisecond
+
+// Gorm routes GORM's logging through slog. Every statement is logged at
+// debug level and slow ones at warn; failed statements are logged at
+// debug only, as callers log the errors they act on with more context.
+type Gorm struct{}
+
+func (g Gorm) LogMode(logger.LogLevel) logger.Interface { return g }
+
+func (Gorm) Info(ctx context.Context, msg string, args ...any) {
+	slog.InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (Gorm) Warn(ctx context.Context, msg string, args ...any) {
+	slog.WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (Gorm) Error(ctx context.Context, msg string, args ...any) {
+	slog.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (Gorm) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	level := slog.LevelDebug
+	if elapsed >= SlowQuery {
+		level = slog.LevelWarn
+	}
+	if !slog.Default().Enabled(ctx, level) {
+		return
+	}
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	msg := "query"
+	if level == slog.LevelWarn {
+		msg = "slow query"
+	}
+	slog.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader is read from the request (e.g. set by the API gateway)
+// and echoed on the response.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen bounds an incoming ID; longer or non-printable values
+// are replaced with a generated one.
+const maxRequestIDLen = 128
+
+// Middleware assigns every request an ID, stores it in the request
+// context and logs one access line per request. 5xx responses are logged
+// at error level and 4xx at warn; requests to skip are not logged.
+func Middleware(skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := EnsureRequestID(c.GetHeader(RequestIDHeader))
+		c.Header(RequestIDHeader, id)
+		ctx := WithRequestID(c.Request.Context(), id)
+		c.Request = c.Request.WithContext(ctx)
+
+		start := time.Now()
+		c.Next()
+		if slices.Contains(skip, c.Request.URL.Path) {
+			return
+		}
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		slog.LogAttrs(ctx, level, "request",
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("size", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+		)
+	}
+}
+
+// EnsureRequestID returns id if it is usable as a request ID and a newly
+// generated one otherwise.
+func EnsureRequestID(id string) string {
+	if validRequestID(id) {
+		return id
+	}
+	return newRequestID()
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Recovery turns a panic in a handler into a logged error and a 500
+// response instead of gin's plain-text stack dump on stderr.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, err any) {
+		ctx := c.Request.Context()
+		slog.ErrorContext(ctx, "panic", "error", err, "route", c.FullPath(), "stack", string(debug.Stack()))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "InternalServerError", "message": "Internal server error", "request_id": RequestID(ctx),
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"school-api/internal/models"
+	"school-api/internal/tenant"
+)
+
+// scrapeTimeout bounds the journal query run on every scrape.
+const scrapeTimeout = 2 * time.Second
+
+// journalCollector reports today's journal activity, queried at scrape
+// time so an alert fires when teachers stop logging lessons.
+type journalCollector struct {
+	db         *gorm.DB
+	lessons    *prometheus.Desc
+	entries    *prometheus.Desc
+	attendance *prometheus.Desc
+}
+
+func newJournalCollector(db *gorm.DB) *journalCollector {
+	return &journalCollector{
+		db: db,
+		lessons: prometheus.NewDesc(namespace+"_lessons_logged_today",
+			"Lesson log entries dated today.", nil, nil),
+		entries: prometheus.NewDesc(namespace+"_student_lessons_today",
+			"Attendance and grade entries for today's lessons.", nil, nil),
+		attendance: prometheus.NewDesc(namespace+"_attendance_rate_today",
+			"Share of today's attendance entries that are not absences; absent while there are none.", nil, nil),
+	}
+}
+
+func (j *journalCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- j.lessons
+	ch <- j.entries
+	ch <- j.attendance
+}
+
+func (j *journalCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(tenant.AllSchools(context.Background()), scrapeTimeout)
+	defer cancel()
+
+	var today struct {
+		Lessons int64
+		Entries int64
+		Present int64
+	}
+	err := j.db.WithContext(ctx).Raw(`
+		SELECT count(DISTINCT ll.id) AS lessons,
+		       count(sl.id) AS entries,
+		       count(sl.id) FILTER (WHERE sl.attendance_status <> ?) AS present
+		FROM lesson_logs ll
+		LEFT JOIN student_lessons sl ON sl.lesson_id = ll.id
+		WHERE ll.date = CURRENT_DATE`, models.AttendanceAbsent).Scan(&today).Error
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(j.lessons, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(j.lessons, prometheus.GaugeValue, float64(today.Lessons))
+	ch <- prometheus.MustNewConstMetric(j.entries, prometheus.GaugeValue, float64(today.Entries))
+	if today.Entries > 0 {
+		ch <- prometheus.MustNewConstMetric(j.attendance, prometheus.GaugeValue, float64(today.Present)/float64(today.Entries))
+	}
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// APIKey lets a machine client call the API with "Authorization: ApiKey
+// <key>". Only a SHA-256 hash of the key is stored; Prefix identifies the
+// key for lookup and display. Scopes is a comma-separated list.
+type APIKey struct {
+    ID         uint       `json:"id" gorm:"primaryKey"`
+    SchoolID   uint       `json:"school_id" gorm:"not null;default:1;index"`
+    Name       string     `json:"name" gorm:"type:varchar(100);not null"`
+    Prefix     string     `json:"prefix" gorm:"type:varchar(20);not null;uniqueIndex"`
+    Hash       string     `json:"-" gorm:"type:varchar(64);not null"`
+    Scopes     string     `json:"scopes" gorm:"type:text;not null"`
+    ExpiresAt  *time.Time `json:"expires_at"`
+    RevokedAt  *time.Time `json:"revoked_at"`
+    LastUsedAt *time.Time `json:"last_used_at"`
+    CreatedAt  time.Time  `json:"created_at"`
+}
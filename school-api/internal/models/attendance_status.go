@@ -1,5 +1,10 @@
 package models
 
+// AttendanceAbsent is the attendance code of a missed lesson; it raises
+// absence events and counts against the attendance rate.
+const AttendanceAbsent = "A"
+
+// AttendanceStatus is reference data shared by all schools.
 type AttendanceStatus struct {
     Code        string `json:"code" gorm:"type:char(1);primaryKey"`
     Description string `json:"description" gorm:"type:varchar(50);not null"`
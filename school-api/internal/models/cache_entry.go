@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// CacheEntry is a cached response body shared by all API instances when
+// the reference data cache uses the postgres backend.
+type CacheEntry struct {
+    Key       string    `json:"key" gorm:"primaryKey;type:varchar(300)"`
+    Body      []byte    `json:"body" gorm:"not null"`
+    Modified  time.Time `json:"modified" gorm:"not null"`
+    ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+}
@@ -1,7 +1,11 @@
 package models
 
 type Class struct {
-	ID     uint   `json:"id" gorm:"primaryKey"`
-	Grade  int    `json:"grade" gorm:"not null;check:grade >= 1 AND grade <= 12"`
-	Letter string `json:"letter" gorm:"type:char(1);not null;check:letter ~ '^[A-Z]'"`
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	SchoolID uint   `json:"school_id" gorm:"not null;default:1;index"`
+	Grade    int    `json:"grade" gorm:"not null;check:grade >= 1 AND grade <= 12"`
+	Letter   string `json:"letter" gorm:"type:char(1);not null;check:letter ~ '^[A-Z]'"`
+	// GraduatedYear is set by the year-end rollover once the class has
+	// finished school; such classes are kept for history only.
+	GraduatedYear *int `json:"graduated_year,omitempty"`
 }
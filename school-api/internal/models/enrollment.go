@@ -0,0 +1,15 @@
+package models
+
+// Enrollment is a period during which a student belonged to a class.
+// The period is half-open: [StartDate, EndDate). A nil StartDate means
+// the student was in the class since before history was recorded; a nil
+// EndDate marks the current enrollment.
+type Enrollment struct {
+    ID        uint    `json:"id" gorm:"primaryKey"`
+    SchoolID  uint    `json:"school_id" gorm:"not null;default:1;index"`
+    StudentID uint    `json:"student_id" gorm:"not null;index"`
+    ClassID   uint    `json:"class_id" gorm:"not null;index"`
+    StartDate *string `json:"start_date" gorm:"type:date"`
+    EndDate   *string `json:"end_date" gorm:"type:date"`
+    Reason    string  `json:"reason" gorm:"type:varchar(200)"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// Guardian is a parent or other legal representative of students.
+type Guardian struct {
+    ID         uint      `json:"id" gorm:"primaryKey"`
+    SchoolID   uint      `json:"school_id" gorm:"not null;default:1;index"`
+    FirstName  string    `json:"first_name" gorm:"type:varchar(50);not null"`
+    LastName   string    `json:"last_name" gorm:"type:varchar(50);not null"`
+    Patronymic string    `json:"patronymic" gorm:"type:varchar(50)"`
+    Phone      string    `json:"phone" gorm:"type:varchar(20)"`
+    Email      string    `json:"email" gorm:"type:varchar(100)"`
+    Language   string    `json:"language" gorm:"type:varchar(5);not null;default:ru"`
+    Students   []Student `json:"students,omitempty" gorm:"many2many:guardian_students"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Homework is work assigned during a lesson and due at a later lesson of
+// the same subject.
+type Homework struct {
+    ID          uint                 `json:"id" gorm:"primaryKey"`
+    SchoolID    uint                 `json:"school_id" gorm:"not null;default:1;index"`
+    LessonID    uint                 `json:"lesson_id" gorm:"not null;index"`
+    Description string               `json:"description" gorm:"type:text;not null"`
+    DueDate     string               `json:"due_date" gorm:"type:date;not null;index"`
+    Attachments []HomeworkAttachment `json:"attachments" gorm:"foreignKey:HomeworkID;constraint:OnDelete:CASCADE"`
+
+    // Associations, populated only when requested via ?expand=
+    Lesson *LessonLog `json:"lesson,omitempty" gorm:"foreignKey:LessonID"`
+}
+
+// HomeworkAttachment is a link to material that comes with a homework.
+type HomeworkAttachment struct {
+    ID         uint   `json:"id" gorm:"primaryKey"`
+    SchoolID   uint   `json:"school_id" gorm:"not null;default:1;index"`
+    HomeworkID uint   `json:"homework_id" gorm:"not null;index"`
+    Name       string `json:"name" gorm:"type:varchar(200);not null"`
+    URL        string `json:"url" gorm:"type:varchar(1000);not null"`
+}
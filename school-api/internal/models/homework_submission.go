@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// HomeworkSubmission is a file a student handed in for a homework. A
+// student has at most one submission per homework; resubmitting replaces
+// the file until the submission is graded.
+type HomeworkSubmission struct {
+    ID          uint       `json:"id" gorm:"primaryKey"`
+    SchoolID    uint       `json:"school_id" gorm:"not null;default:1;index"`
+    HomeworkID  uint       `json:"homework_id" gorm:"not null;uniqueIndex:uq_submissions_homework_student"`
+    StudentID   uint       `json:"student_id" gorm:"not null;uniqueIndex:uq_submissions_homework_student"`
+    FileKey     string     `json:"-" gorm:"type:varchar(300);not null"`
+    FileName    string     `json:"file_name" gorm:"type:varchar(255);not null"`
+    ContentType string     `json:"content_type" gorm:"type:varchar(100);not null"`
+    Size        int64      `json:"size" gorm:"not null"`
+    SubmittedAt time.Time  `json:"submitted_at" gorm:"not null"`
+    Late        bool       `json:"late" gorm:"not null;default:false"`
+    Grade       *int       `json:"grade"`
+    Comment     string     `json:"comment" gorm:"type:text"`
+    GradedAt    *time.Time `json:"graded_at"`
+}
@@ -2,11 +2,16 @@ package models
 
 type LessonLog struct {
     ID        uint   `json:"id" gorm:"primaryKey"`
+    SchoolID  uint   `json:"school_id" gorm:"not null;default:1;index"`
     SubjectID uint   `json:"subject_id" gorm:"not null"`
     Date      string `json:"date" gorm:"type:date;not null"`
     Number    int    `json:"number" gorm:"not null;check:number >= 1 AND number <= 8"`
     ClassID   uint   `json:"class_id" gorm:"not null"`
     TeacherID uint   `json:"teacher_id" gorm:"not null"`
-}
 
+    // Associations, populated only when requested via ?expand=
+    Subject *Subject `json:"subject,omitempty" gorm:"foreignKey:SubjectID"`
+    Class   *Class   `json:"class,omitempty" gorm:"foreignKey:ClassID"`
+    Teacher *Teacher `json:"teacher,omitempty" gorm:"foreignKey:TeacherID"`
+}
 
@@ -2,11 +2,15 @@ package models
 
 type LessonSchedule struct {
     ID        uint `json:"id" gorm:"primaryKey"`
+    SchoolID  uint `json:"school_id" gorm:"not null;default:1;index"`
     SubjectID uint `json:"subject_id" gorm:"not null"`
     Weekday   int  `json:"weekday" gorm:"not null;check:weekday >= 1 AND weekday <= 7"`
     Number    int  `json:"number" gorm:"not null;check:number >= 1 AND number <= 8"`
     ClassID   uint `json:"class_id" gorm:"not null"`
     TeacherID uint `json:"teacher_id" gorm:"not null"`
-}
 
+    // Associations, populated only when preloaded
+    Subject *Subject `json:"subject,omitempty" gorm:"foreignKey:SubjectID"`
+    Teacher *Teacher `json:"teacher,omitempty" gorm:"foreignKey:TeacherID"`
+}
 
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Notification kinds.
+const (
+    NotificationAbsence  = "absence"
+    NotificationLowGrade = "low_grade"
+)
+
+// Notification is an event a guardian should be told about. Pending
+// notifications are batched into one digest email per guardian per day.
+type Notification struct {
+    ID         uint       `json:"id" gorm:"primaryKey"`
+    SchoolID   uint       `json:"school_id" gorm:"not null;default:1;index"`
+    GuardianID uint       `json:"guardian_id" gorm:"not null;index"`
+    StudentID  uint       `json:"student_id" gorm:"not null"`
+    Kind       string     `json:"kind" gorm:"type:varchar(20);not null"`
+    Data       string     `json:"data" gorm:"type:jsonb;not null"`
+    CreatedAt  time.Time  `json:"created_at" gorm:"index"`
+    SentAt     *time.Time `json:"sent_at" gorm:"index"`
+}
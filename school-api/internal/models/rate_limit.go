@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RateLimitBucket is a token bucket shared by all API instances when rate
+// limiting uses the postgres backend. Allowed is the outcome of the latest
+// request taken from the bucket.
+type RateLimitBucket struct {
+    Key       string    `json:"key" gorm:"primaryKey;type:varchar(300)"`
+    Tokens    float64   `json:"tokens" gorm:"not null"`
+    Allowed   bool      `json:"allowed" gorm:"not null"`
+    UpdatedAt time.Time `json:"updated_at" gorm:"not null;index"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RolloverRun records a completed year-end rollover so it cannot be
+// applied twice for the same academic year in a school.
+type RolloverRun struct {
+    ID        uint      `json:"id" gorm:"primaryKey"`
+    SchoolID  uint      `json:"school_id" gorm:"not null;default:1;uniqueIndex:idx_rollover_runs_school_year"`
+    Year      int       `json:"year" gorm:"not null;uniqueIndex:idx_rollover_runs_school_year"`
+    Summary   string    `json:"summary" gorm:"type:jsonb;not null"`
+    CreatedAt time.Time `json:"created_at"`
+}
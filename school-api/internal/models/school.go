@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// DefaultSchoolID is the school that rows created before multi-school
+// tenancy belong to.
+const DefaultSchoolID = 1
+
+// School is a tenant. Every other model except AttendanceStatus carries a
+// SchoolID and is only visible within its school; Slug is the subdomain
+// the school is served on (e.g. "school42" for school42.example.org).
+type School struct {
+    ID        uint      `json:"id" gorm:"primaryKey"`
+    Name      string    `json:"name" gorm:"type:varchar(200);not null"`
+    Slug      string    `json:"slug" gorm:"type:varchar(63);not null;uniqueIndex"`
+    CreatedAt time.Time `json:"created_at"`
+}
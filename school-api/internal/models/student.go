@@ -1,11 +1,18 @@
 package models
 
+// Student statuses.
+const (
+    StudentActive = "active"
+    StudentAlumni = "alumni"
+)
+
 type Student struct {
     ID         uint   `json:"id" gorm:"primaryKey"`
+    SchoolID   uint   `json:"school_id" gorm:"not null;default:1;index"`
     ClassID    uint   `json:"class_id" gorm:"not null"`
     FirstName  string `json:"first_name" gorm:"type:varchar(50);not null"`
     LastName   string `json:"last_name" gorm:"type:varchar(50);not null"`
     Patronymic string `json:"patronymic" gorm:"type:varchar(50)"`
+    Status     string `json:"status" gorm:"type:varchar(10);not null;default:active"`
 }
 
-
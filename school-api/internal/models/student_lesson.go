@@ -2,10 +2,14 @@ package models
 
 type StudentLesson struct {
     ID               uint   `json:"id" gorm:"primaryKey"`
+    SchoolID         uint   `json:"school_id" gorm:"not null;default:1;index"`
     StudentID        uint   `json:"student_id" gorm:"not null"`
     LessonID         uint   `json:"lesson_id" gorm:"not null"`
     Grade            *int   `json:"grade"`
     AttendanceStatus string `json:"attendance_status" gorm:"type:char(1);not null"`
-}
 
+    // Associations, populated only when requested via ?expand=
+    Student *Student   `json:"student,omitempty" gorm:"foreignKey:StudentID"`
+    Lesson  *LessonLog `json:"lesson,omitempty" gorm:"foreignKey:LessonID"`
+}
 
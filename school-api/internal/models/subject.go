@@ -2,6 +2,7 @@ package models
 
 type Subject struct {
     ID          uint   `json:"id" gorm:"primaryKey"`
+    SchoolID    uint   `json:"school_id" gorm:"not null;default:1;index"`
     SubjectName string `json:"subject_name" gorm:"type:varchar(100);not null"`
 }
 
@@ -2,6 +2,7 @@ package models
 
 type Teacher struct {
     ID         uint   `json:"id" gorm:"primaryKey"`
+    SchoolID   uint   `json:"school_id" gorm:"not null;default:1;index"`
     FirstName  string `json:"first_name" gorm:"type:varchar(50);not null"`
     LastName   string `json:"last_name" gorm:"type:varchar(50);not null"`
     Patronymic string `json:"patronymic" gorm:"type:varchar(50)"`
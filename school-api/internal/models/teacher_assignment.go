@@ -2,6 +2,7 @@ package models
 
 type TeacherAssignment struct {
     ID        uint `json:"id" gorm:"primaryKey"`
+    SchoolID  uint `json:"school_id" gorm:"not null;default:1;index"`
     TeacherID uint `json:"teacher_id" gorm:"not null"`
     SubjectID uint `json:"subject_id" gorm:"not null"`
 }
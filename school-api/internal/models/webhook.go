@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// WebhookSubscription is an external endpoint that receives signed event
+// notifications. EventTypes is a comma-separated list; "*" matches all.
+type WebhookSubscription struct {
+    ID         uint      `json:"id" gorm:"primaryKey"`
+    SchoolID   uint      `json:"school_id" gorm:"not null;default:1;index"`
+    URL        string    `json:"url" gorm:"type:varchar(1000);not null"`
+    EventTypes string    `json:"event_types" gorm:"type:text;not null"`
+    Secret     string    `json:"-" gorm:"type:varchar(200);not null"`
+    Active     bool      `json:"active" gorm:"not null;default:true"`
+    CreatedAt  time.Time `json:"created_at"`
+}
+
+// Webhook delivery statuses.
+const (
+    DeliveryPending   = "pending"
+    DeliverySucceeded = "succeeded"
+    DeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is one event queued for one subscription, together with
+// the outcome of the latest attempt.
+type WebhookDelivery struct {
+    ID             uint       `json:"id" gorm:"primaryKey"`
+    SchoolID       uint       `json:"school_id" gorm:"not null;default:1;index"`
+    SubscriptionID uint       `json:"subscription_id" gorm:"not null;index"`
+    EventID        string     `json:"event_id" gorm:"type:varchar(64);not null"`
+    EventType      string     `json:"event_type" gorm:"type:varchar(100);not null"`
+    Payload        string     `json:"payload" gorm:"type:jsonb;not null"`
+    Status         string     `json:"status" gorm:"type:varchar(20);not null;index"`
+    Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+    NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"not null;index"`
+    ResponseStatus int        `json:"response_status"`
+    LastError      string     `json:"last_error" gorm:"type:text"`
+    CreatedAt      time.Time  `json:"created_at"`
+    DeliveredAt    *time.Time `json:"delivered_at"`
+}
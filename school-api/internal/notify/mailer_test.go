@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuildMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		to       *mail.Address
+		subject  string
+		body     string
+		wantBody string
+	}{
+		{
+			name:     "plain",
+			to:       &mail.Address{Address: "anna@example.com"},
+			subject:  "School journal: 2024-09-02",
+			body:     "line one\nline two",
+			wantBody: "line one\r\nline two",
+		},
+		{
+			name:     "named",
+			to:       &mail.Address{Name: "Анна Петрова", Address: "anna@example.com"},
+			subject:  "Школьный журнал: 2024-09-02",
+			body:     "Здравствуйте!",
+			wantBody: "Здравствуйте!",
+		},
+		{
+			name:     "subject with line breaks",
+			to:       &mail.Address{Address: "anna@example.com"},
+			subject:  "hi\r\nBcc: evil@example.com",
+			body:     "",
+			wantBody: "",
+		},
+	}
+	from := &mail.Address{Address: "journal@school.local"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := mail.ReadMessage(bytes.NewReader(buildMessage(from, tt.to, tt.subject, tt.body)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			to, err := msg.Header.AddressList("To")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(to) != 1 || *to[0] != *tt.to {
+				t.Errorf("To = %v, want %v", to, tt.to)
+			}
+			if got := msg.Header.Get("From"); got != "<journal@school.local>" {
+				t.Errorf("From = %q", got)
+			}
+			if got := msg.Header["Bcc"]; got != nil {
+				t.Errorf("Bcc = %q, want none", got)
+			}
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if subject != tt.subject {
+				t.Errorf("Subject = %q, want %q", subject, tt.subject)
+			}
+			body, _ := io.ReadAll(msg.Body)
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSendRejectsInvalidAddresses(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     string
+	}{
+		{"journal@school.local", "anna@example.com\r\nBcc: evil@example.com", "recipient"},
+		{"journal@school.local", "anna@example.com\nX-Injected: 1", "recipient"},
+		{"journal@school.local", "anna@example.com, evil@example.com", "recipient"},
+		{"journal@school.local", "not an address", "recipient"},
+		{"journal@school.local", "", "recipient"},
+		{"journal\r\n@school.local", "anna@example.com", "sender"},
+	}
+	for _, tt := range tests {
+		// Nothing listens on port 1: the addresses must be refused first.
+		m := SMTPMailer{Config: SMTPConfig{Host: "127.0.0.1", Port: "1", From: tt.from}}
+		err := m.Send(tt.to, "subject", "body")
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want+" ") {
+			t.Errorf("Send(from %q, to %q) = %v, want a %s error", tt.from, tt.to, err, tt.want)
+		}
+	}
+}
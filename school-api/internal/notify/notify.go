@@ -0,0 +1,272 @@
+// Package notify emails guardians about absences and low grades.
+//
+// Journal events are turned into Notification rows as they happen; a
+// background worker sends each guardian at most one digest email per day
+// containing everything collected since the previous digest, rendered in
+// the guardian's language.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"school-api/internal/enrollment"
+	"school-api/internal/events"
+	"school-api/internal/models"
+	"school-api/internal/tenant"
+)
+
+// DefaultLanguage is used for guardians with no or an unsupported language.
+const DefaultLanguage = "ru"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// itemTemplate renders one notification by dispatching on its kind.
+const itemTemplate = `{{define "item"}}{{if eq .Kind "absence"}}{{template "absence" .Data}}{{else if eq .Kind "low_grade"}}{{template "low_grade" .Data}}{{end}}{{end}}`
+
+// Data describes the lesson a notification is about.
+type Data struct {
+	StudentName string `json:"student_name"`
+	Subject     string `json:"subject"`
+	Date        string `json:"date"`
+	Number      int    `json:"number"`
+	Grade       *int   `json:"grade,omitempty"`
+}
+
+// Item is one notification as seen by templates.
+type Item struct {
+	Kind string
+	Data Data
+}
+
+// Digest is the template context of a digest email.
+type Digest struct {
+	Guardian models.Guardian
+	Date     string
+	Items    []Item
+}
+
+// Notifier collects notifications and sends daily digests.
+type Notifier struct {
+	DB     *gorm.DB
+	Mailer Mailer
+	// LowGradeThreshold: grades at or below it are reported.
+	LowGradeThreshold int
+	// DigestHour is the local hour (0-23) at which digests go out.
+	DigestHour int
+	// Interval is how often the worker checks whether a digest is due.
+	Interval time.Duration
+
+	templates map[string]*template.Template
+}
+
+// New parses the templates for every language found in templates/.
+func New(db *gorm.DB, mailer Mailer, lowGradeThreshold, digestHour int) (*Notifier, error) {
+	n := &Notifier{
+		DB:                db,
+		Mailer:            mailer,
+		LowGradeThreshold: lowGradeThreshold,
+		DigestHour:        digestHour,
+		Interval:          time.Minute,
+		templates:         map[string]*template.Template{},
+	}
+	files, err := templateFS.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		// digest.<lang>.tmpl defines the languages.
+		parts := strings.Split(f.Name(), ".")
+		if len(parts) != 3 || parts[0] != "digest" {
+			continue
+		}
+		lang := parts[1]
+		t, err := template.New(lang).Parse(itemTemplate)
+		if err != nil {
+			return nil, err
+		}
+		if t, err = t.ParseFS(templateFS, "templates/*."+lang+".tmpl"); err != nil {
+			return nil, fmt.Errorf("templates for %s: %w", lang, err)
+		}
+		n.templates[lang] = t
+	}
+	if n.templates[DefaultLanguage] == nil {
+		return nil, fmt.Errorf("missing templates for default language %q", DefaultLanguage)
+	}
+	return n, nil
+}
+
+// Enqueue is an events.Subscriber that records notifications for the
+// student's guardians on absences and low grades.
+func (n *Notifier) Enqueue(ctx context.Context, e events.Event) {
+	data, ok := e.Data.(events.StudentLessonData)
+	if !ok {
+		return
+	}
+	var kind string
+	switch {
+	case e.Type == events.AbsenceRecorded:
+		kind = models.NotificationAbsence
+	case e.Type == events.GradeRecorded && data.Grade != nil && *data.Grade <= n.LowGradeThreshold:
+		kind = models.NotificationLowGrade
+	default:
+		return
+	}
+	if err := n.enqueue(ctx, kind, data.StudentLesson); err != nil {
+		slog.Error("notify: enqueue failed", "kind", kind, "student_id", data.StudentID, "error", err)
+	}
+}
+
+func (n *Notifier) enqueue(ctx context.Context, kind string, sl models.StudentLesson) error {
+	db := n.DB.WithContext(ctx)
+	var guardianIDs []uint
+	if err := db.Table("guardian_students").Where("student_id = ?", sl.StudentID).
+		Pluck("guardian_id", &guardianIDs).Error; err != nil {
+		return err
+	}
+	if len(guardianIDs) == 0 {
+		return nil
+	}
+	var student models.Student
+	if err := db.First(&student, sl.StudentID).Error; err != nil {
+		return err
+	}
+	var lesson models.LessonLog
+	if err := db.Preload("Subject").First(&lesson, sl.LessonID).Error; err != nil {
+		return err
+	}
+	d := Data{
+		StudentName: strings.TrimSpace(student.FirstName + " " + student.LastName),
+		Date:        enrollment.DateOnly(lesson.Date),
+		Number:      lesson.Number,
+		Grade:       sl.Grade,
+	}
+	if lesson.Subject != nil {
+		d.Subject = lesson.Subject.SubjectName
+	}
+	raw, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	rows := make([]models.Notification, len(guardianIDs))
+	for i, gid := range guardianIDs {
+		rows[i] = models.Notification{GuardianID: gid, StudentID: sl.StudentID, Kind: kind, Data: string(raw)}
+	}
+	return db.Create(&rows).Error
+}
+
+// Run sends digests until ctx is cancelled.
+func (n *Notifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(n.Interval)
+	defer ticker.Stop()
+	for {
+		if err := n.SendDigests(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			slog.Error("notify: send digests failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// cutoff returns the most recent digest time at or before now.
+func (n *Notifier) cutoff(now time.Time) time.Time {
+	c := time.Date(now.Year(), now.Month(), now.Day(), n.DigestHour, 0, 0, 0, now.Location())
+	if c.After(now) {
+		c = c.AddDate(0, 0, -1)
+	}
+	return c
+}
+
+// SendDigests emails every guardian with notifications created before the
+// latest digest time. It is safe to call often and from several instances:
+// rows are claimed with SKIP LOCKED and marked sent in the same transaction.
+func (n *Notifier) SendDigests(ctx context.Context, now time.Time) error {
+	ctx = tenant.AllSchools(ctx)
+	cutoff := n.cutoff(now)
+	var guardianIDs []uint
+	if err := n.DB.WithContext(ctx).Model(&models.Notification{}).
+		Where("sent_at IS NULL AND created_at < ?", cutoff).
+		Distinct().Pluck("guardian_id", &guardianIDs).Error; err != nil {
+		return err
+	}
+	for _, gid := range guardianIDs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := n.sendDigest(ctx, gid, cutoff); err != nil {
+			slog.Error("notify: digest failed", "guardian_id", gid, "error", err)
+		}
+	}
+	return nil
+}
+
+func (n *Notifier) sendDigest(ctx context.Context, guardianID uint, cutoff time.Time) error {
+	return n.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []models.Notification
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("guardian_id = ? AND sent_at IS NULL AND created_at < ?", guardianID, cutoff).
+			Order("created_at").Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		var guardian models.Guardian
+		if err := tx.First(&guardian, guardianID).Error; err != nil {
+			return err
+		}
+		if guardian.Email != "" {
+			subject, body, err := n.Render(guardian, cutoff, rows)
+			if err != nil {
+				return err
+			}
+			if err := n.Mailer.Send(guardian.Email, subject, body); err != nil {
+				return err
+			}
+		}
+		ids := make([]uint, len(rows))
+		for i, r := range rows {
+			ids[i] = r.ID
+		}
+		// Guardians without an email are marked too, so the queue drains.
+		return tx.Model(&models.Notification{}).Where("id IN ?", ids).Update("sent_at", time.Now()).Error
+	})
+}
+
+// Render produces the digest subject and body in the guardian's language.
+func (n *Notifier) Render(g models.Guardian, date time.Time, rows []models.Notification) (string, string, error) {
+	t := n.templates[g.Language]
+	if t == nil {
+		t = n.templates[DefaultLanguage]
+	}
+	digest := Digest{Guardian: g, Date: date.Format("2006-01-02")}
+	for _, r := range rows {
+		var d Data
+		if err := json.Unmarshal([]byte(r.Data), &d); err != nil {
+			return "", "", err
+		}
+		digest.Items = append(digest.Items, Item{Kind: r.Kind, Data: d})
+	}
+	var subject, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", digest); err != nil {
+		return "", "", err
+	}
+	if err := t.ExecuteTemplate(&body, "body", digest); err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
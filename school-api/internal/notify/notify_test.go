@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"school-api/internal/models"
+)
+
+func TestRender(t *testing.T) {
+	n, err := New(nil, nil, 2, 18)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := []models.Notification{
+		{Kind: "absence", Data: `{"student_name":"Ivan Petrov","subject":"Math","date":"2024-09-02","number":3}`},
+		{Kind: "low_grade", Data: `{"student_name":"Ivan Petrov","subject":"History","date":"2024-09-02","number":4,"grade":2}`},
+	}
+	date := time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		language    string
+		wantSubject string
+		wantBody    []string
+	}{
+		{
+			language:    "ru",
+			wantSubject: "Школьный журнал: 2024-09-02",
+			wantBody: []string{
+				"Здравствуйте, Anna Sergeevna!",
+				"- Ivan Petrov отсутствовал(а) на уроке «Math» 2024-09-02 (3-й урок).",
+				"- Ivan Petrov получил(а) оценку 2 по предмету «History» 2024-09-02.",
+			},
+		},
+		{
+			language:    "en",
+			wantSubject: "School journal: 2024-09-02",
+			wantBody: []string{
+				"Hello Anna Petrova,",
+				"- Ivan Petrov was absent from Math on 2024-09-02 (lesson 3).",
+				"- Ivan Petrov received a grade of 2 in History on 2024-09-02.",
+			},
+		},
+		{
+			// Unsupported languages fall back to DefaultLanguage.
+			language:    "de",
+			wantSubject: "Школьный журнал: 2024-09-02",
+			wantBody:    []string{"Здравствуйте, Anna Sergeevna!"},
+		},
+		{
+			language:    "",
+			wantSubject: "Школьный журнал: 2024-09-02",
+			wantBody:    []string{"Здравствуйте, Anna Sergeevna!"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			g := models.Guardian{FirstName: "Anna", LastName: "Petrova", Patronymic: "Sergeevna", Language: tt.language}
+			subject, body, err := n.Render(g, date, rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if subject != tt.wantSubject {
+				t.Errorf("subject = %q, want %q", subject, tt.wantSubject)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("body does not contain %q:\n%s", want, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderRejectsBadData(t *testing.T) {
+	n, err := New(nil, nil, 2, 18)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := []models.Notification{{Kind: "absence", Data: "not json"}}
+	if _, _, err := n.Render(models.Guardian{}, time.Now(), rows); err == nil {
+		t.Error("Render succeeded, want error")
+	}
+}
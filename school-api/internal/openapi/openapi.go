@@ -0,0 +1,362 @@
+// Package openapi builds the OpenAPI document of the REST API from the gin
+// route table and the Go types the handlers bind and return, and validates
+// incoming requests against it.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/gin-gonic/gin"
+)
+
+// Param documents a query parameter or a multipart form field.
+type Param struct {
+	Name        string
+	Type        string // "integer", "string", "boolean" or "file"
+	Format      string // e.g. "date"
+	Description string
+	Required    bool
+	Min, Max    *float64
+	Enum        []string
+}
+
+// Operation documents one route. Path uses gin syntax (/classes/:id) and
+// is relative to the router root.
+type Operation struct {
+	Method  string
+	Path    string
+	Tag     string
+	Summary string
+	Query   []Param
+	// Body is a value of the JSON request body type, nil for none.
+	Body any
+	// BodyOptional marks a body that may be omitted.
+	BodyOptional bool
+	// Form lists multipart/form-data fields, used instead of Body.
+	Form []Param
+	// Response is a value of the JSON response type, nil for no content.
+	Response any
+	// List wraps the response as {"data": [Response]}.
+	List bool
+	// Status is the success status, 200 by default.
+	Status int
+	// Binary marks a response that is a raw file download.
+	Binary bool
+	// Auth marks routes that require a bearer token.
+	Auth bool
+}
+
+// Info is the document title and version.
+type Info struct {
+	Title   string
+	Version string
+}
+
+// Range returns min and max bounds for Param.
+func Range(min, max float64) (*float64, *float64) { return &min, &max }
+
+// Build returns the document for routes. Routes without an Operation are
+// still listed with their path parameters, so the document always covers
+// the whole API; an Operation without a route is an error, as it means the
+// documentation went stale.
+func Build(info Info, routes gin.RoutesInfo, ops []Operation) (*openapi3.T, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: info.Title, Version: info.Version},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: openapi3.Schemas{},
+			SecuritySchemes: openapi3.SecuritySchemes{
+				"bearerAuth": &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme()},
+			},
+		},
+	}
+	doc.Components.Schemas["Error"] = openapi3.NewSchemaRef("", openapi3.NewObjectSchema().
+		WithProperty("error", openapi3.NewStringSchema()).
+		WithProperty("message", openapi3.NewStringSchema()))
+	// openapi3gen refers to time.Time as a component without exporting it.
+	doc.Components.Schemas["DateTime"] = openapi3.NewDateTimeSchema().NewRef()
+
+	gen := openapi3gen.NewGenerator(
+		openapi3gen.SchemaCustomizer(customize),
+		openapi3gen.CreateTypeNameGenerator(typeName),
+		openapi3gen.CreateComponentSchemas(openapi3gen.ExportComponentSchemasOptions{
+			ExportComponentSchemas: true,
+			ExportTopLevelSchema:   true,
+		}),
+	)
+
+	documented := map[string]Operation{}
+	for _, op := range ops {
+		documented[op.Method+" "+op.Path] = op
+	}
+	seen := map[string]bool{}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+	for _, r := range routes {
+		key := r.Method + " " + r.Path
+		seen[key] = true
+		op, ok := documented[key]
+		if !ok {
+			op = Operation{Method: r.Method, Path: r.Path}
+		}
+		o, err := operation(gen, doc.Components.Schemas, op)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		path := oasPath(r.Path)
+		item := doc.Paths.Value(path)
+		if item == nil {
+			item = &openapi3.PathItem{}
+			doc.Paths.Set(path, item)
+		}
+		item.SetOperation(r.Method, o)
+	}
+	for key := range documented {
+		if !seen[key] {
+			return nil, fmt.Errorf("documented route %s is not registered", key)
+		}
+	}
+	if err := openapi3.NewLoader().ResolveRefsIn(doc, nil); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func operation(gen *openapi3gen.Generator, schemas openapi3.Schemas, op Operation) (*openapi3.Operation, error) {
+	o := openapi3.NewOperation()
+	o.Summary = op.Summary
+	o.OperationID = operationID(op.Method, op.Path)
+	if op.Tag != "" {
+		o.Tags = []string{op.Tag}
+	}
+	if op.Auth {
+		o.Security = &openapi3.SecurityRequirements{{"bearerAuth": []string{}}}
+	}
+	for _, name := range pathParams(op.Path) {
+		p := openapi3.NewPathParameter(name)
+		if name == "code" {
+			p.Schema = openapi3.NewStringSchema().NewRef()
+		} else {
+			p.Schema = openapi3.NewIntegerSchema().WithMin(1).NewRef()
+		}
+		o.AddParameter(p)
+	}
+	for _, q := range op.Query {
+		p := openapi3.NewQueryParameter(q.Name).WithDescription(q.Description).WithRequired(q.Required)
+		p.Schema = paramSchema(q).NewRef()
+		o.AddParameter(p)
+	}
+
+	switch {
+	case op.Body != nil:
+		ref, err := gen.NewSchemaRefForValue(op.Body, schemas)
+		if err != nil {
+			return nil, err
+		}
+		body := openapi3.NewRequestBody().WithJSONSchemaRef(ref).WithRequired(!op.BodyOptional)
+		o.RequestBody = &openapi3.RequestBodyRef{Value: body}
+	case len(op.Form) > 0:
+		form := openapi3.NewObjectSchema()
+		for _, f := range op.Form {
+			form.WithProperty(f.Name, paramSchema(f))
+			if f.Required {
+				form.Required = append(form.Required, f.Name)
+			}
+		}
+		body := openapi3.NewRequestBody().WithRequired(true).
+			WithContent(openapi3.NewContentWithFormDataSchema(form))
+		o.RequestBody = &openapi3.RequestBodyRef{Value: body}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	ok := openapi3.NewResponse().WithDescription(http.StatusText(status))
+	switch {
+	case op.Binary:
+		ok.WithContent(openapi3.Content{"application/octet-stream": openapi3.NewMediaType().
+			WithSchema(openapi3.NewStringSchema().WithFormat("binary"))})
+	case op.Response != nil:
+		ref, err := gen.NewSchemaRefForValue(op.Response, schemas)
+		if err != nil {
+			return nil, err
+		}
+		if op.List {
+			ref = openapi3.NewObjectSchema().WithProperty("data",
+				&openapi3.Schema{Type: &openapi3.Types{openapi3.TypeArray}, Items: ref}).NewRef()
+		}
+		ok.WithJSONSchemaRef(ref)
+	}
+	o.Responses = openapi3.NewResponses(
+		openapi3.WithStatus(status, &openapi3.ResponseRef{Value: ok}),
+		openapi3.WithName("default", openapi3.NewResponse().WithDescription("Error").
+			WithJSONSchemaRef(openapi3.NewSchemaRef("#/components/schemas/Error", nil))),
+	)
+	return o, nil
+}
+
+func paramSchema(p Param) *openapi3.Schema {
+	var s *openapi3.Schema
+	switch p.Type {
+	case "integer":
+		s = openapi3.NewIntegerSchema()
+	case "boolean":
+		s = openapi3.NewBoolSchema()
+	case "file":
+		s = openapi3.NewStringSchema().WithFormat("binary")
+	default:
+		s = openapi3.NewStringSchema()
+	}
+	if p.Format != "" {
+		s.Format = p.Format
+	}
+	if p.Min != nil {
+		s.WithMin(*p.Min)
+	}
+	if p.Max != nil {
+		s.WithMax(*p.Max)
+	}
+	for _, e := range p.Enum {
+		s.Enum = append(s.Enum, e)
+	}
+	return s
+}
+
+var paramPattern = regexp.MustCompile(`[:*]([A-Za-z_]+)`)
+
+// oasPath converts /classes/:id to /classes/{id}.
+func oasPath(p string) string {
+	return paramPattern.ReplaceAllString(p, "{$1}")
+}
+
+func pathParams(p string) []string {
+	var names []string
+	for _, m := range paramPattern.FindAllStringSubmatch(p, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// operationID derives a stable ID such as get_classes_id from the route.
+func operationID(method, path string) string {
+	id := strings.ToLower(method) + strings.NewReplacer("/", "_", ":", "", "*", "", "-", "_").Replace(path)
+	return strings.TrimSuffix(id, "_")
+}
+
+// typeName names component schemas: models keep their name, other
+// packages are prefixed with the package name (RolloverOptions).
+func typeName(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "DateTime"
+	}
+	name := t.Name()
+	pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+	switch pkg {
+	case "models":
+		return name
+	case "handlers":
+		return title(name)
+	default:
+		return title(pkg) + title(name)
+	}
+}
+
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var (
+	sizePattern  = regexp.MustCompile(`type:(?:var)?char\((\d+)\)`)
+	rangePattern = regexp.MustCompile(`check:\w+ >= (\d+) AND \w+ <= (\d+)`)
+	bindingBound = regexp.MustCompile(`\b(min|max)=(\d+)`)
+	// foreignKeyPattern finds the child field of a has-many association.
+	foreignKeyPattern = regexp.MustCompile(`foreignKey:(\w+)`)
+)
+
+// customize derives validation rules from the struct tags of models and
+// inputs: gorm column sizes and range checks, and binding min/max of
+// numbers, become maxLength and minimum/maximum, primary keys are read-only, and "not null" columns
+// without a default or binding:"required" fields are required.
+func customize(name string, t reflect.Type, tag reflect.StructTag, s *openapi3.Schema) error {
+	if tag.Get("json") == "-" {
+		return &openapi3gen.ExcludeSchemaSentinel{}
+	}
+	gormTag := tag.Get("gorm")
+	if m := sizePattern.FindStringSubmatch(gormTag); m != nil && s.Type.Is(openapi3.TypeString) {
+		n, _ := strconv.ParseUint(m[1], 10, 64)
+		s.MaxLength = &n
+	}
+	if m := rangePattern.FindStringSubmatch(gormTag); m != nil {
+		min, _ := strconv.ParseFloat(m[1], 64)
+		max, _ := strconv.ParseFloat(m[2], 64)
+		s.WithMin(min).WithMax(max)
+	}
+	if s.Type.Is(openapi3.TypeInteger) || s.Type.Is(openapi3.TypeNumber) {
+		for _, m := range bindingBound.FindAllStringSubmatch(tag.Get("binding"), -1) {
+			v, _ := strconv.ParseFloat(m[2], 64)
+			if m[1] == "min" {
+				s.WithMin(v)
+			} else {
+				s.WithMax(v)
+			}
+		}
+	}
+	if strings.Contains(gormTag, "primaryKey") && !s.Type.Is(openapi3.TypeString) {
+		s.ReadOnly = true
+	}
+	if t.Kind() == reflect.Struct && t.PkgPath() != "time" {
+		s.Required = requiredFields(t)
+	}
+	if m := foreignKeyPattern.FindStringSubmatch(gormTag); m != nil && t.Kind() == reflect.Slice && s.Items != nil && s.Items.Value != nil {
+		setByParent(t.Elem(), m[1], s.Items.Value)
+	}
+	return nil
+}
+
+// setByParent marks the foreign key field of a has-many child read-only
+// and not required: the parent fills it in when it saves its children.
+func setByParent(t reflect.Type, field string, s *openapi3.Schema) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	f, ok := t.FieldByName(field)
+	if t.Kind() != reflect.Struct || !ok {
+		return
+	}
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if p := s.Properties[name]; p != nil && p.Value != nil {
+		p.Value.ReadOnly = true
+	}
+	s.Required = slices.DeleteFunc(s.Required, func(r string) bool { return r == name })
+}
+
+func requiredFields(t reflect.Type) []string {
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if jsonName == "" || jsonName == "-" {
+			continue
+		}
+		gormTag := f.Tag.Get("gorm")
+		notNull := strings.Contains(gormTag, "not null") && !strings.Contains(gormTag, "default:")
+		if notNull || strings.Contains(f.Tag.Get("binding"), "required") {
+			required = append(required, jsonName)
+		}
+	}
+	return required
+}
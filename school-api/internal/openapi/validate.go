@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+
+	"school-api/internal/logging"
+)
+
+// Validator rejects requests whose query parameters or JSON body do not
+// match the document. The document is built from the finished route table,
+// so the middleware is installed first and the document loaded afterwards;
+// until then requests pass through.
+type Validator struct {
+	routes atomic.Pointer[map[string]*routers.Route]
+}
+
+// Load indexes the operations of doc by method and path.
+func (v *Validator) Load(doc *openapi3.T) {
+	routes := map[string]*routers.Route{}
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			routes[method+" "+path] = &routers.Route{
+				Spec: doc, Path: path, PathItem: item, Method: method, Operation: op,
+			}
+		}
+	}
+	v.routes.Store(&routes)
+}
+
+// Middleware validates the request against the operation of the matched
+// gin route and answers 400 on mismatch. Multipart bodies are left to the
+// handler, which streams the upload.
+func (v *Validator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		routes := v.routes.Load()
+		if routes == nil {
+			c.Next()
+			return
+		}
+		route := (*routes)[c.Request.Method+" "+oasPath(c.FullPath())]
+		if route == nil {
+			c.Next()
+			return
+		}
+		params := make(map[string]string, len(c.Params))
+		for _, p := range c.Params {
+			params[p.Key] = p.Value
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: params,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				AuthenticationFunc:         openapi3filter.NoopAuthenticationFunc,
+				ExcludeReadOnlyValidations: true,
+				SkipSettingDefaults:        true,
+				ExcludeRequestBody:         isMultipart(route.Operation),
+			},
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "BadRequest", "message": message(err), "request_id": logging.RequestID(c.Request.Context())})
+			return
+		}
+		c.Next()
+	}
+}
+
+func isMultipart(op *openapi3.Operation) bool {
+	return op.RequestBody != nil && op.RequestBody.Value.Content.Get("multipart/form-data") != nil
+}
+
+// message names the offending parameter or body field without echoing the
+// schema, e.g. `body field "first_name": maximum string length is 50`.
+func message(err error) string {
+	var reqErr *openapi3filter.RequestError
+	if !errors.As(err, &reqErr) {
+		return err.Error()
+	}
+	where := "body"
+	if reqErr.Parameter != nil {
+		where = "parameter " + strconv.Quote(reqErr.Parameter.Name)
+	}
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		if field := strings.Join(schemaErr.JSONPointer(), "."); field != "" {
+			where += " field " + strconv.Quote(field)
+		}
+		return where + ": " + schemaErr.Reason
+	}
+	if reqErr.Err != nil {
+		return where + ": " + reqErr.Err.Error()
+	}
+	return where + ": " + reqErr.Reason
+}
@@ -0,0 +1,5 @@
+// Package pb contains the protobuf messages and gRPC service stubs generated
+// from journal.proto.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative journal.proto
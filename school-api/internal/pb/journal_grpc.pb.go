@@ -0,0 +1,2444 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: journal.proto
+
+// Journal entities and CRUD services. The gRPC server runs in the same
+// process as the REST API and shares its service layer, so natural-key
+// checks and events behave identically on both.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ClassService_List_FullMethodName     = "/journal.v1.ClassService/List"
+	ClassService_Get_FullMethodName      = "/journal.v1.ClassService/Get"
+	ClassService_Create_FullMethodName   = "/journal.v1.ClassService/Create"
+	ClassService_Update_FullMethodName   = "/journal.v1.ClassService/Update"
+	ClassService_Delete_FullMethodName   = "/journal.v1.ClassService/Delete"
+	ClassService_Students_FullMethodName = "/journal.v1.ClassService/Students"
+)
+
+// ClassServiceClient is the client API for ClassService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ClassServiceClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListClassesResponse, error)
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Class, error)
+	Create(ctx context.Context, in *Class, opts ...grpc.CallOption) (*Class, error)
+	Update(ctx context.Context, in *Class, opts ...grpc.CallOption) (*Class, error)
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Roster of the class on a date, from enrollment history.
+	Students(ctx context.Context, in *ClassStudentsRequest, opts ...grpc.CallOption) (*ListStudentsResponse, error)
+}
+
+type classServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClassServiceClient(cc grpc.ClientConnInterface) ClassServiceClient {
+	return &classServiceClient{cc}
+}
+
+func (c *classServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListClassesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListClassesResponse)
+	err := c.cc.Invoke(ctx, ClassService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *classServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Class, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Class)
+	err := c.cc.Invoke(ctx, ClassService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *classServiceClient) Create(ctx context.Context, in *Class, opts ...grpc.CallOption) (*Class, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Class)
+	err := c.cc.Invoke(ctx, ClassService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *classServiceClient) Update(ctx context.Context, in *Class, opts ...grpc.CallOption) (*Class, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Class)
+	err := c.cc.Invoke(ctx, ClassService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *classServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ClassService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *classServiceClient) Students(ctx context.Context, in *ClassStudentsRequest, opts ...grpc.CallOption) (*ListStudentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStudentsResponse)
+	err := c.cc.Invoke(ctx, ClassService_Students_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClassServiceServer is the server API for ClassService service.
+// All implementations must embed UnimplementedClassServiceServer
+// for forward compatibility.
+type ClassServiceServer interface {
+	List(context.Context, *ListRequest) (*ListClassesResponse, error)
+	Get(context.Context, *IdRequest) (*Class, error)
+	Create(context.Context, *Class) (*Class, error)
+	Update(context.Context, *Class) (*Class, error)
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	// Roster of the class on a date, from enrollment history.
+	Students(context.Context, *ClassStudentsRequest) (*ListStudentsResponse, error)
+	mustEmbedUnimplementedClassServiceServer()
+}
+
+// UnimplementedClassServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedClassServiceServer struct{}
+
+func (UnimplementedClassServiceServer) List(context.Context, *ListRequest) (*ListClassesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedClassServiceServer) Get(context.Context, *IdRequest) (*Class, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedClassServiceServer) Create(context.Context, *Class) (*Class, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedClassServiceServer) Update(context.Context, *Class) (*Class, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedClassServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedClassServiceServer) Students(context.Context, *ClassStudentsRequest) (*ListStudentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Students not implemented")
+}
+func (UnimplementedClassServiceServer) mustEmbedUnimplementedClassServiceServer() {}
+func (UnimplementedClassServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeClassServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClassServiceServer will
+// result in compilation errors.
+type UnsafeClassServiceServer interface {
+	mustEmbedUnimplementedClassServiceServer()
+}
+
+func RegisterClassServiceServer(s grpc.ServiceRegistrar, srv ClassServiceServer) {
+	// If the following call pancis, it indicates UnimplementedClassServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ClassService_ServiceDesc, srv)
+}
+
+func _ClassService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClassServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClassService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClassServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClassService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClassServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClassService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClassServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClassService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Class)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClassServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClassService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClassServiceServer).Create(ctx, req.(*Class))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClassService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Class)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClassServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClassService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClassServiceServer).Update(ctx, req.(*Class))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClassService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClassServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClassService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClassServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClassService_Students_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClassStudentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClassServiceServer).Students(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClassService_Students_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClassServiceServer).Students(ctx, req.(*ClassStudentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ClassService_ServiceDesc is the grpc.ServiceDesc for ClassService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ClassService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.ClassService",
+	HandlerType: (*ClassServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _ClassService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _ClassService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _ClassService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _ClassService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _ClassService_Delete_Handler,
+		},
+		{
+			MethodName: "Students",
+			Handler:    _ClassService_Students_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
+
+const (
+	StudentService_List_FullMethodName   = "/journal.v1.StudentService/List"
+	StudentService_Get_FullMethodName    = "/journal.v1.StudentService/Get"
+	StudentService_Create_FullMethodName = "/journal.v1.StudentService/Create"
+	StudentService_Update_FullMethodName = "/journal.v1.StudentService/Update"
+	StudentService_Delete_FullMethodName = "/journal.v1.StudentService/Delete"
+)
+
+// StudentServiceClient is the client API for StudentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StudentServiceClient interface {
+	List(ctx context.Context, in *ListStudentsRequest, opts ...grpc.CallOption) (*ListStudentsResponse, error)
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Student, error)
+	Create(ctx context.Context, in *Student, opts ...grpc.CallOption) (*Student, error)
+	// A changed class_id is recorded as a transfer effective today.
+	Update(ctx context.Context, in *Student, opts ...grpc.CallOption) (*Student, error)
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type studentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStudentServiceClient(cc grpc.ClientConnInterface) StudentServiceClient {
+	return &studentServiceClient{cc}
+}
+
+func (c *studentServiceClient) List(ctx context.Context, in *ListStudentsRequest, opts ...grpc.CallOption) (*ListStudentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStudentsResponse)
+	err := c.cc.Invoke(ctx, StudentService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Student, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Student)
+	err := c.cc.Invoke(ctx, StudentService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentServiceClient) Create(ctx context.Context, in *Student, opts ...grpc.CallOption) (*Student, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Student)
+	err := c.cc.Invoke(ctx, StudentService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentServiceClient) Update(ctx context.Context, in *Student, opts ...grpc.CallOption) (*Student, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Student)
+	err := c.cc.Invoke(ctx, StudentService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StudentService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StudentServiceServer is the server API for StudentService service.
+// All implementations must embed UnimplementedStudentServiceServer
+// for forward compatibility.
+type StudentServiceServer interface {
+	List(context.Context, *ListStudentsRequest) (*ListStudentsResponse, error)
+	Get(context.Context, *IdRequest) (*Student, error)
+	Create(context.Context, *Student) (*Student, error)
+	// A changed class_id is recorded as a transfer effective today.
+	Update(context.Context, *Student) (*Student, error)
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedStudentServiceServer()
+}
+
+// UnimplementedStudentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStudentServiceServer struct{}
+
+func (UnimplementedStudentServiceServer) List(context.Context, *ListStudentsRequest) (*ListStudentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedStudentServiceServer) Get(context.Context, *IdRequest) (*Student, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedStudentServiceServer) Create(context.Context, *Student) (*Student, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedStudentServiceServer) Update(context.Context, *Student) (*Student, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedStudentServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedStudentServiceServer) mustEmbedUnimplementedStudentServiceServer() {}
+func (UnimplementedStudentServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeStudentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StudentServiceServer will
+// result in compilation errors.
+type UnsafeStudentServiceServer interface {
+	mustEmbedUnimplementedStudentServiceServer()
+}
+
+func RegisterStudentServiceServer(s grpc.ServiceRegistrar, srv StudentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStudentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StudentService_ServiceDesc, srv)
+}
+
+func _StudentService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStudentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentServiceServer).List(ctx, req.(*ListStudentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Student)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentServiceServer).Create(ctx, req.(*Student))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Student)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentServiceServer).Update(ctx, req.(*Student))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StudentService_ServiceDesc is the grpc.ServiceDesc for StudentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StudentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.StudentService",
+	HandlerType: (*StudentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _StudentService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _StudentService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _StudentService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _StudentService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _StudentService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
+
+const (
+	TeacherService_List_FullMethodName   = "/journal.v1.TeacherService/List"
+	TeacherService_Get_FullMethodName    = "/journal.v1.TeacherService/Get"
+	TeacherService_Create_FullMethodName = "/journal.v1.TeacherService/Create"
+	TeacherService_Update_FullMethodName = "/journal.v1.TeacherService/Update"
+	TeacherService_Delete_FullMethodName = "/journal.v1.TeacherService/Delete"
+)
+
+// TeacherServiceClient is the client API for TeacherService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TeacherServiceClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListTeachersResponse, error)
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Teacher, error)
+	Create(ctx context.Context, in *Teacher, opts ...grpc.CallOption) (*Teacher, error)
+	Update(ctx context.Context, in *Teacher, opts ...grpc.CallOption) (*Teacher, error)
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type teacherServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTeacherServiceClient(cc grpc.ClientConnInterface) TeacherServiceClient {
+	return &teacherServiceClient{cc}
+}
+
+func (c *teacherServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListTeachersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTeachersResponse)
+	err := c.cc.Invoke(ctx, TeacherService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teacherServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Teacher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Teacher)
+	err := c.cc.Invoke(ctx, TeacherService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teacherServiceClient) Create(ctx context.Context, in *Teacher, opts ...grpc.CallOption) (*Teacher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Teacher)
+	err := c.cc.Invoke(ctx, TeacherService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teacherServiceClient) Update(ctx context.Context, in *Teacher, opts ...grpc.CallOption) (*Teacher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Teacher)
+	err := c.cc.Invoke(ctx, TeacherService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teacherServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TeacherService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TeacherServiceServer is the server API for TeacherService service.
+// All implementations must embed UnimplementedTeacherServiceServer
+// for forward compatibility.
+type TeacherServiceServer interface {
+	List(context.Context, *ListRequest) (*ListTeachersResponse, error)
+	Get(context.Context, *IdRequest) (*Teacher, error)
+	Create(context.Context, *Teacher) (*Teacher, error)
+	Update(context.Context, *Teacher) (*Teacher, error)
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedTeacherServiceServer()
+}
+
+// UnimplementedTeacherServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTeacherServiceServer struct{}
+
+func (UnimplementedTeacherServiceServer) List(context.Context, *ListRequest) (*ListTeachersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedTeacherServiceServer) Get(context.Context, *IdRequest) (*Teacher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedTeacherServiceServer) Create(context.Context, *Teacher) (*Teacher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedTeacherServiceServer) Update(context.Context, *Teacher) (*Teacher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedTeacherServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedTeacherServiceServer) mustEmbedUnimplementedTeacherServiceServer() {}
+func (UnimplementedTeacherServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeTeacherServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TeacherServiceServer will
+// result in compilation errors.
+type UnsafeTeacherServiceServer interface {
+	mustEmbedUnimplementedTeacherServiceServer()
+}
+
+func RegisterTeacherServiceServer(s grpc.ServiceRegistrar, srv TeacherServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTeacherServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TeacherService_ServiceDesc, srv)
+}
+
+func _TeacherService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeacherService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeacherService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Teacher)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherServiceServer).Create(ctx, req.(*Teacher))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeacherService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Teacher)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherServiceServer).Update(ctx, req.(*Teacher))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeacherService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TeacherService_ServiceDesc is the grpc.ServiceDesc for TeacherService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TeacherService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.TeacherService",
+	HandlerType: (*TeacherServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _TeacherService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _TeacherService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _TeacherService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _TeacherService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TeacherService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
+
+const (
+	SubjectService_List_FullMethodName   = "/journal.v1.SubjectService/List"
+	SubjectService_Get_FullMethodName    = "/journal.v1.SubjectService/Get"
+	SubjectService_Create_FullMethodName = "/journal.v1.SubjectService/Create"
+	SubjectService_Update_FullMethodName = "/journal.v1.SubjectService/Update"
+	SubjectService_Delete_FullMethodName = "/journal.v1.SubjectService/Delete"
+)
+
+// SubjectServiceClient is the client API for SubjectService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SubjectServiceClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListSubjectsResponse, error)
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Subject, error)
+	Create(ctx context.Context, in *Subject, opts ...grpc.CallOption) (*Subject, error)
+	Update(ctx context.Context, in *Subject, opts ...grpc.CallOption) (*Subject, error)
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type subjectServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSubjectServiceClient(cc grpc.ClientConnInterface) SubjectServiceClient {
+	return &subjectServiceClient{cc}
+}
+
+func (c *subjectServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListSubjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubjectsResponse)
+	err := c.cc.Invoke(ctx, SubjectService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subjectServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*Subject, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Subject)
+	err := c.cc.Invoke(ctx, SubjectService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subjectServiceClient) Create(ctx context.Context, in *Subject, opts ...grpc.CallOption) (*Subject, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Subject)
+	err := c.cc.Invoke(ctx, SubjectService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subjectServiceClient) Update(ctx context.Context, in *Subject, opts ...grpc.CallOption) (*Subject, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Subject)
+	err := c.cc.Invoke(ctx, SubjectService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subjectServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SubjectService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SubjectServiceServer is the server API for SubjectService service.
+// All implementations must embed UnimplementedSubjectServiceServer
+// for forward compatibility.
+type SubjectServiceServer interface {
+	List(context.Context, *ListRequest) (*ListSubjectsResponse, error)
+	Get(context.Context, *IdRequest) (*Subject, error)
+	Create(context.Context, *Subject) (*Subject, error)
+	Update(context.Context, *Subject) (*Subject, error)
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedSubjectServiceServer()
+}
+
+// UnimplementedSubjectServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSubjectServiceServer struct{}
+
+func (UnimplementedSubjectServiceServer) List(context.Context, *ListRequest) (*ListSubjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedSubjectServiceServer) Get(context.Context, *IdRequest) (*Subject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedSubjectServiceServer) Create(context.Context, *Subject) (*Subject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedSubjectServiceServer) Update(context.Context, *Subject) (*Subject, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedSubjectServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedSubjectServiceServer) mustEmbedUnimplementedSubjectServiceServer() {}
+func (UnimplementedSubjectServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeSubjectServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SubjectServiceServer will
+// result in compilation errors.
+type UnsafeSubjectServiceServer interface {
+	mustEmbedUnimplementedSubjectServiceServer()
+}
+
+func RegisterSubjectServiceServer(s grpc.ServiceRegistrar, srv SubjectServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSubjectServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SubjectService_ServiceDesc, srv)
+}
+
+func _SubjectService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubjectService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubjectService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Subject)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).Create(ctx, req.(*Subject))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubjectService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Subject)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).Update(ctx, req.(*Subject))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SubjectService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubjectServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubjectService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubjectServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SubjectService_ServiceDesc is the grpc.ServiceDesc for SubjectService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SubjectService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.SubjectService",
+	HandlerType: (*SubjectServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _SubjectService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _SubjectService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _SubjectService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _SubjectService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _SubjectService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
+
+const (
+	TeacherAssignmentService_List_FullMethodName   = "/journal.v1.TeacherAssignmentService/List"
+	TeacherAssignmentService_Get_FullMethodName    = "/journal.v1.TeacherAssignmentService/Get"
+	TeacherAssignmentService_Create_FullMethodName = "/journal.v1.TeacherAssignmentService/Create"
+	TeacherAssignmentService_Update_FullMethodName = "/journal.v1.TeacherAssignmentService/Update"
+	TeacherAssignmentService_Delete_FullMethodName = "/journal.v1.TeacherAssignmentService/Delete"
+)
+
+// TeacherAssignmentServiceClient is the client API for TeacherAssignmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TeacherAssignmentServiceClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListTeacherAssignmentsResponse, error)
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*TeacherAssignment, error)
+	Create(ctx context.Context, in *TeacherAssignment, opts ...grpc.CallOption) (*TeacherAssignment, error)
+	Update(ctx context.Context, in *TeacherAssignment, opts ...grpc.CallOption) (*TeacherAssignment, error)
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type teacherAssignmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTeacherAssignmentServiceClient(cc grpc.ClientConnInterface) TeacherAssignmentServiceClient {
+	return &teacherAssignmentServiceClient{cc}
+}
+
+func (c *teacherAssignmentServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListTeacherAssignmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTeacherAssignmentsResponse)
+	err := c.cc.Invoke(ctx, TeacherAssignmentService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teacherAssignmentServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*TeacherAssignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TeacherAssignment)
+	err := c.cc.Invoke(ctx, TeacherAssignmentService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teacherAssignmentServiceClient) Create(ctx context.Context, in *TeacherAssignment, opts ...grpc.CallOption) (*TeacherAssignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TeacherAssignment)
+	err := c.cc.Invoke(ctx, TeacherAssignmentService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teacherAssignmentServiceClient) Update(ctx context.Context, in *TeacherAssignment, opts ...grpc.CallOption) (*TeacherAssignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TeacherAssignment)
+	err := c.cc.Invoke(ctx, TeacherAssignmentService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teacherAssignmentServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TeacherAssignmentService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TeacherAssignmentServiceServer is the server API for TeacherAssignmentService service.
+// All implementations must embed UnimplementedTeacherAssignmentServiceServer
+// for forward compatibility.
+type TeacherAssignmentServiceServer interface {
+	List(context.Context, *ListRequest) (*ListTeacherAssignmentsResponse, error)
+	Get(context.Context, *IdRequest) (*TeacherAssignment, error)
+	Create(context.Context, *TeacherAssignment) (*TeacherAssignment, error)
+	Update(context.Context, *TeacherAssignment) (*TeacherAssignment, error)
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedTeacherAssignmentServiceServer()
+}
+
+// UnimplementedTeacherAssignmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTeacherAssignmentServiceServer struct{}
+
+func (UnimplementedTeacherAssignmentServiceServer) List(context.Context, *ListRequest) (*ListTeacherAssignmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedTeacherAssignmentServiceServer) Get(context.Context, *IdRequest) (*TeacherAssignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedTeacherAssignmentServiceServer) Create(context.Context, *TeacherAssignment) (*TeacherAssignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedTeacherAssignmentServiceServer) Update(context.Context, *TeacherAssignment) (*TeacherAssignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedTeacherAssignmentServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedTeacherAssignmentServiceServer) mustEmbedUnimplementedTeacherAssignmentServiceServer() {
+}
+func (UnimplementedTeacherAssignmentServiceServer) testEmbeddedByValue() {}
+
+// UnsafeTeacherAssignmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TeacherAssignmentServiceServer will
+// result in compilation errors.
+type UnsafeTeacherAssignmentServiceServer interface {
+	mustEmbedUnimplementedTeacherAssignmentServiceServer()
+}
+
+func RegisterTeacherAssignmentServiceServer(s grpc.ServiceRegistrar, srv TeacherAssignmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTeacherAssignmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TeacherAssignmentService_ServiceDesc, srv)
+}
+
+func _TeacherAssignmentService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherAssignmentServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherAssignmentService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherAssignmentServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeacherAssignmentService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherAssignmentServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherAssignmentService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherAssignmentServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeacherAssignmentService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeacherAssignment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherAssignmentServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherAssignmentService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherAssignmentServiceServer).Create(ctx, req.(*TeacherAssignment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeacherAssignmentService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TeacherAssignment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherAssignmentServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherAssignmentService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherAssignmentServiceServer).Update(ctx, req.(*TeacherAssignment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TeacherAssignmentService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeacherAssignmentServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TeacherAssignmentService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeacherAssignmentServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TeacherAssignmentService_ServiceDesc is the grpc.ServiceDesc for TeacherAssignmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TeacherAssignmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.TeacherAssignmentService",
+	HandlerType: (*TeacherAssignmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _TeacherAssignmentService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _TeacherAssignmentService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _TeacherAssignmentService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _TeacherAssignmentService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TeacherAssignmentService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
+
+const (
+	LessonScheduleService_List_FullMethodName   = "/journal.v1.LessonScheduleService/List"
+	LessonScheduleService_Get_FullMethodName    = "/journal.v1.LessonScheduleService/Get"
+	LessonScheduleService_Create_FullMethodName = "/journal.v1.LessonScheduleService/Create"
+	LessonScheduleService_Update_FullMethodName = "/journal.v1.LessonScheduleService/Update"
+	LessonScheduleService_Delete_FullMethodName = "/journal.v1.LessonScheduleService/Delete"
+)
+
+// LessonScheduleServiceClient is the client API for LessonScheduleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LessonScheduleServiceClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListLessonSchedulesResponse, error)
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*LessonSchedule, error)
+	Create(ctx context.Context, in *LessonSchedule, opts ...grpc.CallOption) (*LessonSchedule, error)
+	Update(ctx context.Context, in *LessonSchedule, opts ...grpc.CallOption) (*LessonSchedule, error)
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type lessonScheduleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLessonScheduleServiceClient(cc grpc.ClientConnInterface) LessonScheduleServiceClient {
+	return &lessonScheduleServiceClient{cc}
+}
+
+func (c *lessonScheduleServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListLessonSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLessonSchedulesResponse)
+	err := c.cc.Invoke(ctx, LessonScheduleService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lessonScheduleServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*LessonSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LessonSchedule)
+	err := c.cc.Invoke(ctx, LessonScheduleService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lessonScheduleServiceClient) Create(ctx context.Context, in *LessonSchedule, opts ...grpc.CallOption) (*LessonSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LessonSchedule)
+	err := c.cc.Invoke(ctx, LessonScheduleService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lessonScheduleServiceClient) Update(ctx context.Context, in *LessonSchedule, opts ...grpc.CallOption) (*LessonSchedule, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LessonSchedule)
+	err := c.cc.Invoke(ctx, LessonScheduleService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lessonScheduleServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LessonScheduleService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LessonScheduleServiceServer is the server API for LessonScheduleService service.
+// All implementations must embed UnimplementedLessonScheduleServiceServer
+// for forward compatibility.
+type LessonScheduleServiceServer interface {
+	List(context.Context, *ListRequest) (*ListLessonSchedulesResponse, error)
+	Get(context.Context, *IdRequest) (*LessonSchedule, error)
+	Create(context.Context, *LessonSchedule) (*LessonSchedule, error)
+	Update(context.Context, *LessonSchedule) (*LessonSchedule, error)
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedLessonScheduleServiceServer()
+}
+
+// UnimplementedLessonScheduleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLessonScheduleServiceServer struct{}
+
+func (UnimplementedLessonScheduleServiceServer) List(context.Context, *ListRequest) (*ListLessonSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedLessonScheduleServiceServer) Get(context.Context, *IdRequest) (*LessonSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedLessonScheduleServiceServer) Create(context.Context, *LessonSchedule) (*LessonSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedLessonScheduleServiceServer) Update(context.Context, *LessonSchedule) (*LessonSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedLessonScheduleServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedLessonScheduleServiceServer) mustEmbedUnimplementedLessonScheduleServiceServer() {}
+func (UnimplementedLessonScheduleServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeLessonScheduleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LessonScheduleServiceServer will
+// result in compilation errors.
+type UnsafeLessonScheduleServiceServer interface {
+	mustEmbedUnimplementedLessonScheduleServiceServer()
+}
+
+func RegisterLessonScheduleServiceServer(s grpc.ServiceRegistrar, srv LessonScheduleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLessonScheduleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LessonScheduleService_ServiceDesc, srv)
+}
+
+func _LessonScheduleService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonScheduleServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonScheduleService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonScheduleServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LessonScheduleService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonScheduleServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonScheduleService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonScheduleServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LessonScheduleService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LessonSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonScheduleServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonScheduleService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonScheduleServiceServer).Create(ctx, req.(*LessonSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LessonScheduleService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LessonSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonScheduleServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonScheduleService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonScheduleServiceServer).Update(ctx, req.(*LessonSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LessonScheduleService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonScheduleServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonScheduleService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonScheduleServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LessonScheduleService_ServiceDesc is the grpc.ServiceDesc for LessonScheduleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LessonScheduleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.LessonScheduleService",
+	HandlerType: (*LessonScheduleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _LessonScheduleService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _LessonScheduleService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _LessonScheduleService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _LessonScheduleService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _LessonScheduleService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
+
+const (
+	LessonLogService_List_FullMethodName   = "/journal.v1.LessonLogService/List"
+	LessonLogService_Stream_FullMethodName = "/journal.v1.LessonLogService/Stream"
+	LessonLogService_Get_FullMethodName    = "/journal.v1.LessonLogService/Get"
+	LessonLogService_Create_FullMethodName = "/journal.v1.LessonLogService/Create"
+	LessonLogService_Update_FullMethodName = "/journal.v1.LessonLogService/Update"
+	LessonLogService_Delete_FullMethodName = "/journal.v1.LessonLogService/Delete"
+)
+
+// LessonLogServiceClient is the client API for LessonLogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LessonLogServiceClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListLessonLogsResponse, error)
+	// Streams lessons matching the filter, one message per lesson.
+	Stream(ctx context.Context, in *StreamLessonLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LessonLog], error)
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*LessonLog, error)
+	Create(ctx context.Context, in *LessonLog, opts ...grpc.CallOption) (*LessonLog, error)
+	Update(ctx context.Context, in *LessonLog, opts ...grpc.CallOption) (*LessonLog, error)
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type lessonLogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLessonLogServiceClient(cc grpc.ClientConnInterface) LessonLogServiceClient {
+	return &lessonLogServiceClient{cc}
+}
+
+func (c *lessonLogServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListLessonLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLessonLogsResponse)
+	err := c.cc.Invoke(ctx, LessonLogService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lessonLogServiceClient) Stream(ctx context.Context, in *StreamLessonLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LessonLog], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LessonLogService_ServiceDesc.Streams[0], LessonLogService_Stream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamLessonLogsRequest, LessonLog]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LessonLogService_StreamClient = grpc.ServerStreamingClient[LessonLog]
+
+func (c *lessonLogServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*LessonLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LessonLog)
+	err := c.cc.Invoke(ctx, LessonLogService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lessonLogServiceClient) Create(ctx context.Context, in *LessonLog, opts ...grpc.CallOption) (*LessonLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LessonLog)
+	err := c.cc.Invoke(ctx, LessonLogService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lessonLogServiceClient) Update(ctx context.Context, in *LessonLog, opts ...grpc.CallOption) (*LessonLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LessonLog)
+	err := c.cc.Invoke(ctx, LessonLogService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lessonLogServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, LessonLogService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LessonLogServiceServer is the server API for LessonLogService service.
+// All implementations must embed UnimplementedLessonLogServiceServer
+// for forward compatibility.
+type LessonLogServiceServer interface {
+	List(context.Context, *ListRequest) (*ListLessonLogsResponse, error)
+	// Streams lessons matching the filter, one message per lesson.
+	Stream(*StreamLessonLogsRequest, grpc.ServerStreamingServer[LessonLog]) error
+	Get(context.Context, *IdRequest) (*LessonLog, error)
+	Create(context.Context, *LessonLog) (*LessonLog, error)
+	Update(context.Context, *LessonLog) (*LessonLog, error)
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedLessonLogServiceServer()
+}
+
+// UnimplementedLessonLogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLessonLogServiceServer struct{}
+
+func (UnimplementedLessonLogServiceServer) List(context.Context, *ListRequest) (*ListLessonLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedLessonLogServiceServer) Stream(*StreamLessonLogsRequest, grpc.ServerStreamingServer[LessonLog]) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (UnimplementedLessonLogServiceServer) Get(context.Context, *IdRequest) (*LessonLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedLessonLogServiceServer) Create(context.Context, *LessonLog) (*LessonLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedLessonLogServiceServer) Update(context.Context, *LessonLog) (*LessonLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedLessonLogServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedLessonLogServiceServer) mustEmbedUnimplementedLessonLogServiceServer() {}
+func (UnimplementedLessonLogServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeLessonLogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LessonLogServiceServer will
+// result in compilation errors.
+type UnsafeLessonLogServiceServer interface {
+	mustEmbedUnimplementedLessonLogServiceServer()
+}
+
+func RegisterLessonLogServiceServer(s grpc.ServiceRegistrar, srv LessonLogServiceServer) {
+	// If the following call pancis, it indicates UnimplementedLessonLogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LessonLogService_ServiceDesc, srv)
+}
+
+func _LessonLogService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonLogServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonLogService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonLogServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LessonLogService_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamLessonLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LessonLogServiceServer).Stream(m, &grpc.GenericServerStream[StreamLessonLogsRequest, LessonLog]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LessonLogService_StreamServer = grpc.ServerStreamingServer[LessonLog]
+
+func _LessonLogService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonLogServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonLogService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonLogServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LessonLogService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LessonLog)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonLogServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonLogService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonLogServiceServer).Create(ctx, req.(*LessonLog))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LessonLogService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LessonLog)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonLogServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonLogService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonLogServiceServer).Update(ctx, req.(*LessonLog))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LessonLogService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LessonLogServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LessonLogService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LessonLogServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LessonLogService_ServiceDesc is the grpc.ServiceDesc for LessonLogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LessonLogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.LessonLogService",
+	HandlerType: (*LessonLogServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _LessonLogService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _LessonLogService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _LessonLogService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _LessonLogService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _LessonLogService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _LessonLogService_Stream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "journal.proto",
+}
+
+const (
+	StudentLessonService_List_FullMethodName   = "/journal.v1.StudentLessonService/List"
+	StudentLessonService_Stream_FullMethodName = "/journal.v1.StudentLessonService/Stream"
+	StudentLessonService_Get_FullMethodName    = "/journal.v1.StudentLessonService/Get"
+	StudentLessonService_Create_FullMethodName = "/journal.v1.StudentLessonService/Create"
+	StudentLessonService_Update_FullMethodName = "/journal.v1.StudentLessonService/Update"
+	StudentLessonService_Delete_FullMethodName = "/journal.v1.StudentLessonService/Delete"
+)
+
+// StudentLessonServiceClient is the client API for StudentLessonService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StudentLessonServiceClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListStudentLessonsResponse, error)
+	// Streams entries whose lesson falls in the date range, one message per
+	// entry, without loading the whole range into memory.
+	Stream(ctx context.Context, in *StreamStudentLessonsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StudentLesson], error)
+	Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*StudentLesson, error)
+	Create(ctx context.Context, in *StudentLesson, opts ...grpc.CallOption) (*StudentLesson, error)
+	Update(ctx context.Context, in *StudentLesson, opts ...grpc.CallOption) (*StudentLesson, error)
+	Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type studentLessonServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStudentLessonServiceClient(cc grpc.ClientConnInterface) StudentLessonServiceClient {
+	return &studentLessonServiceClient{cc}
+}
+
+func (c *studentLessonServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListStudentLessonsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStudentLessonsResponse)
+	err := c.cc.Invoke(ctx, StudentLessonService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentLessonServiceClient) Stream(ctx context.Context, in *StreamStudentLessonsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StudentLesson], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StudentLessonService_ServiceDesc.Streams[0], StudentLessonService_Stream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamStudentLessonsRequest, StudentLesson]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StudentLessonService_StreamClient = grpc.ServerStreamingClient[StudentLesson]
+
+func (c *studentLessonServiceClient) Get(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*StudentLesson, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StudentLesson)
+	err := c.cc.Invoke(ctx, StudentLessonService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentLessonServiceClient) Create(ctx context.Context, in *StudentLesson, opts ...grpc.CallOption) (*StudentLesson, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StudentLesson)
+	err := c.cc.Invoke(ctx, StudentLessonService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentLessonServiceClient) Update(ctx context.Context, in *StudentLesson, opts ...grpc.CallOption) (*StudentLesson, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StudentLesson)
+	err := c.cc.Invoke(ctx, StudentLessonService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentLessonServiceClient) Delete(ctx context.Context, in *IdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StudentLessonService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StudentLessonServiceServer is the server API for StudentLessonService service.
+// All implementations must embed UnimplementedStudentLessonServiceServer
+// for forward compatibility.
+type StudentLessonServiceServer interface {
+	List(context.Context, *ListRequest) (*ListStudentLessonsResponse, error)
+	// Streams entries whose lesson falls in the date range, one message per
+	// entry, without loading the whole range into memory.
+	Stream(*StreamStudentLessonsRequest, grpc.ServerStreamingServer[StudentLesson]) error
+	Get(context.Context, *IdRequest) (*StudentLesson, error)
+	Create(context.Context, *StudentLesson) (*StudentLesson, error)
+	Update(context.Context, *StudentLesson) (*StudentLesson, error)
+	Delete(context.Context, *IdRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedStudentLessonServiceServer()
+}
+
+// UnimplementedStudentLessonServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStudentLessonServiceServer struct{}
+
+func (UnimplementedStudentLessonServiceServer) List(context.Context, *ListRequest) (*ListStudentLessonsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedStudentLessonServiceServer) Stream(*StreamStudentLessonsRequest, grpc.ServerStreamingServer[StudentLesson]) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (UnimplementedStudentLessonServiceServer) Get(context.Context, *IdRequest) (*StudentLesson, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedStudentLessonServiceServer) Create(context.Context, *StudentLesson) (*StudentLesson, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedStudentLessonServiceServer) Update(context.Context, *StudentLesson) (*StudentLesson, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedStudentLessonServiceServer) Delete(context.Context, *IdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedStudentLessonServiceServer) mustEmbedUnimplementedStudentLessonServiceServer() {}
+func (UnimplementedStudentLessonServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeStudentLessonServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StudentLessonServiceServer will
+// result in compilation errors.
+type UnsafeStudentLessonServiceServer interface {
+	mustEmbedUnimplementedStudentLessonServiceServer()
+}
+
+func RegisterStudentLessonServiceServer(s grpc.ServiceRegistrar, srv StudentLessonServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStudentLessonServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StudentLessonService_ServiceDesc, srv)
+}
+
+func _StudentLessonService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentLessonServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentLessonService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentLessonServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentLessonService_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamStudentLessonsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StudentLessonServiceServer).Stream(m, &grpc.GenericServerStream[StreamStudentLessonsRequest, StudentLesson]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StudentLessonService_StreamServer = grpc.ServerStreamingServer[StudentLesson]
+
+func _StudentLessonService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentLessonServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentLessonService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentLessonServiceServer).Get(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentLessonService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StudentLesson)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentLessonServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentLessonService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentLessonServiceServer).Create(ctx, req.(*StudentLesson))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentLessonService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StudentLesson)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentLessonServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentLessonService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentLessonServiceServer).Update(ctx, req.(*StudentLesson))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentLessonService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentLessonServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentLessonService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentLessonServiceServer).Delete(ctx, req.(*IdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StudentLessonService_ServiceDesc is the grpc.ServiceDesc for StudentLessonService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StudentLessonService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.StudentLessonService",
+	HandlerType: (*StudentLessonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _StudentLessonService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _StudentLessonService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _StudentLessonService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _StudentLessonService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _StudentLessonService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _StudentLessonService_Stream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "journal.proto",
+}
+
+const (
+	AttendanceStatusService_List_FullMethodName   = "/journal.v1.AttendanceStatusService/List"
+	AttendanceStatusService_Get_FullMethodName    = "/journal.v1.AttendanceStatusService/Get"
+	AttendanceStatusService_Create_FullMethodName = "/journal.v1.AttendanceStatusService/Create"
+	AttendanceStatusService_Update_FullMethodName = "/journal.v1.AttendanceStatusService/Update"
+	AttendanceStatusService_Delete_FullMethodName = "/journal.v1.AttendanceStatusService/Delete"
+)
+
+// AttendanceStatusServiceClient is the client API for AttendanceStatusService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AttendanceStatusServiceClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListAttendanceStatusesResponse, error)
+	Get(ctx context.Context, in *CodeRequest, opts ...grpc.CallOption) (*AttendanceStatus, error)
+	Create(ctx context.Context, in *AttendanceStatus, opts ...grpc.CallOption) (*AttendanceStatus, error)
+	Update(ctx context.Context, in *AttendanceStatus, opts ...grpc.CallOption) (*AttendanceStatus, error)
+	Delete(ctx context.Context, in *CodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type attendanceStatusServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAttendanceStatusServiceClient(cc grpc.ClientConnInterface) AttendanceStatusServiceClient {
+	return &attendanceStatusServiceClient{cc}
+}
+
+func (c *attendanceStatusServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListAttendanceStatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttendanceStatusesResponse)
+	err := c.cc.Invoke(ctx, AttendanceStatusService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceStatusServiceClient) Get(ctx context.Context, in *CodeRequest, opts ...grpc.CallOption) (*AttendanceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttendanceStatus)
+	err := c.cc.Invoke(ctx, AttendanceStatusService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceStatusServiceClient) Create(ctx context.Context, in *AttendanceStatus, opts ...grpc.CallOption) (*AttendanceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttendanceStatus)
+	err := c.cc.Invoke(ctx, AttendanceStatusService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceStatusServiceClient) Update(ctx context.Context, in *AttendanceStatus, opts ...grpc.CallOption) (*AttendanceStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttendanceStatus)
+	err := c.cc.Invoke(ctx, AttendanceStatusService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceStatusServiceClient) Delete(ctx context.Context, in *CodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AttendanceStatusService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AttendanceStatusServiceServer is the server API for AttendanceStatusService service.
+// All implementations must embed UnimplementedAttendanceStatusServiceServer
+// for forward compatibility.
+type AttendanceStatusServiceServer interface {
+	List(context.Context, *ListRequest) (*ListAttendanceStatusesResponse, error)
+	Get(context.Context, *CodeRequest) (*AttendanceStatus, error)
+	Create(context.Context, *AttendanceStatus) (*AttendanceStatus, error)
+	Update(context.Context, *AttendanceStatus) (*AttendanceStatus, error)
+	Delete(context.Context, *CodeRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAttendanceStatusServiceServer()
+}
+
+// UnimplementedAttendanceStatusServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAttendanceStatusServiceServer struct{}
+
+func (UnimplementedAttendanceStatusServiceServer) List(context.Context, *ListRequest) (*ListAttendanceStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedAttendanceStatusServiceServer) Get(context.Context, *CodeRequest) (*AttendanceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedAttendanceStatusServiceServer) Create(context.Context, *AttendanceStatus) (*AttendanceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedAttendanceStatusServiceServer) Update(context.Context, *AttendanceStatus) (*AttendanceStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedAttendanceStatusServiceServer) Delete(context.Context, *CodeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedAttendanceStatusServiceServer) mustEmbedUnimplementedAttendanceStatusServiceServer() {
+}
+func (UnimplementedAttendanceStatusServiceServer) testEmbeddedByValue() {}
+
+// UnsafeAttendanceStatusServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AttendanceStatusServiceServer will
+// result in compilation errors.
+type UnsafeAttendanceStatusServiceServer interface {
+	mustEmbedUnimplementedAttendanceStatusServiceServer()
+}
+
+func RegisterAttendanceStatusServiceServer(s grpc.ServiceRegistrar, srv AttendanceStatusServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAttendanceStatusServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AttendanceStatusService_ServiceDesc, srv)
+}
+
+func _AttendanceStatusService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceStatusServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceStatusService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceStatusServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceStatusService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceStatusServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceStatusService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceStatusServiceServer).Get(ctx, req.(*CodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceStatusService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttendanceStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceStatusServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceStatusService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceStatusServiceServer).Create(ctx, req.(*AttendanceStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceStatusService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttendanceStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceStatusServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceStatusService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceStatusServiceServer).Update(ctx, req.(*AttendanceStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceStatusService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceStatusServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceStatusService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceStatusServiceServer).Delete(ctx, req.(*CodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AttendanceStatusService_ServiceDesc is the grpc.ServiceDesc for AttendanceStatusService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AttendanceStatusService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.v1.AttendanceStatusService",
+	HandlerType: (*AttendanceStatusServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _AttendanceStatusService_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _AttendanceStatusService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _AttendanceStatusService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _AttendanceStatusService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _AttendanceStatusService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/models"
+)
+
+// Postgres keeps buckets in the rate_limit_buckets table, so that all API
+// instances share one budget per client. Each take is a single upsert.
+type Postgres struct {
+	DB *gorm.DB
+}
+
+// takeSQL refills the bucket for the time since its last use (by the
+// database clock, so instance clocks do not matter) and takes a token if
+// one is left.
+const takeSQL = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+VALUES (@key, @burst - 1, true, now())
+ON CONFLICT (key) DO UPDATE SET
+	allowed = LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate) >= 1,
+	tokens = LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate)
+		- CASE WHEN LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate) >= 1 THEN 1 ELSE 0 END,
+	updated_at = now()
+RETURNING tokens, allowed`
+
+func (p Postgres) Take(ctx context.Context, key string, l Limit) (Result, error) {
+	var row models.RateLimitBucket
+	err := p.DB.WithContext(ctx).Raw(takeSQL, map[string]any{
+		"key": key, "burst": float64(l.Burst), "rate": l.Rate,
+	}).Scan(&row).Error
+	if err != nil {
+		return Result{}, err
+	}
+	return result(l, row.Tokens, row.Allowed), nil
+}
+
+func (p Postgres) Sweep(ctx context.Context, idle time.Duration) error {
+	return p.DB.WithContext(ctx).
+		Where("updated_at < now() - make_interval(secs => ?)", idle.Seconds()).
+		Delete(&models.RateLimitBucket{}).Error
+}
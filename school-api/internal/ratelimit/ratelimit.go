@@ -0,0 +1,173 @@
+// Package ratelimit throttles API clients with token buckets. Every client
+// has one bucket for reads and one for writes; each request takes a token
+// and tokens refill at a steady rate up to the bucket size.
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"school-api/internal/auth"
+	"school-api/internal/config"
+	"school-api/internal/logging"
+)
+
+// Limit is a bucket of Burst tokens refilled at Rate tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// full is how long an empty bucket takes to refill.
+func (l Limit) full() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, zero when allowed.
+	RetryAfter time.Duration
+}
+
+// result describes a bucket left with tokens after a request.
+func result(l Limit, tokens float64, allowed bool) Result {
+	r := Result{Allowed: allowed, Remaining: int(math.Floor(tokens))}
+	r.Reset = time.Duration((float64(l.Burst) - tokens) / l.Rate * float64(time.Second))
+	if !allowed {
+		r.RetryAfter = time.Duration((1 - tokens) / l.Rate * float64(time.Second))
+	}
+	return r
+}
+
+// Store keeps the buckets.
+type Store interface {
+	// Take takes a token from the bucket key, creating a full bucket on
+	// first use.
+	Take(ctx context.Context, key string, l Limit) (Result, error)
+	// Sweep forgets buckets untouched for longer than idle. Such buckets
+	// are full again, so forgetting them changes nothing.
+	Sweep(ctx context.Context, idle time.Duration) error
+}
+
+// Limiter applies the read and write limits to every client.
+type Limiter struct {
+	Store Store
+	Read  Limit
+	Write Limit
+	// Auth identifies token and API key holders; other clients are keyed
+	// by IP.
+	Auth auth.Authenticator
+}
+
+// New returns a limiter with the limits of cfg.
+func New(store Store, cfg config.RateLimit, authn auth.Authenticator) *Limiter {
+	return &Limiter{
+		Store: store,
+		Read:  Limit{Rate: float64(cfg.ReadPerMinute) / 60, Burst: cfg.ReadBurst},
+		Write: Limit{Rate: float64(cfg.WritePerMinute) / 60, Burst: cfg.WriteBurst},
+		Auth:  authn,
+	}
+}
+
+// sweepInterval is how often Run forgets idle buckets.
+const sweepInterval = time.Minute
+
+// Run sweeps idle buckets until ctx is cancelled.
+func (l *Limiter) Run(ctx context.Context) {
+	idle := max(l.Read.full(), l.Write.full(), sweepInterval)
+	t := time.NewTicker(sweepInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := l.Store.Sweep(ctx, idle); err != nil && ctx.Err() == nil {
+				slog.Warn("ratelimit: sweep failed", "error", err)
+			}
+		}
+	}
+}
+
+// Client returns the bucket owner of a request: the API key or the
+// subject of the bearer token when the credentials are valid, otherwise
+// the client IP. The IP honours X-Forwarded-For only from the engine's
+// trusted proxies.
+func (l *Limiter) Client(c *gin.Context) string {
+	p, ok, _ := l.Auth.Identify(c)
+	return ClientKey(p, ok, c.ClientIP())
+}
+
+// ClientKey names the bucket owner of a caller presenting p (ok=false
+// when it has no valid credentials) from ip.
+func ClientKey(p auth.Principal, ok bool, ip string) string {
+	switch {
+	case ok && p.Role == auth.RoleService:
+		return p.Subject // apikey:<id>
+	case ok:
+		return "user:" + p.Subject
+	default:
+		return "ip:" + ip
+	}
+}
+
+// Take takes a token from the read or write bucket of client and returns
+// the limit of that bucket with the outcome.
+func (l *Limiter) Take(ctx context.Context, client string, write bool) (Limit, Result, error) {
+	limit, budget := l.Read, "read"
+	if write {
+		limit, budget = l.Write, "write"
+	}
+	res, err := l.Store.Take(ctx, budget+":"+client, limit)
+	return limit, res, err
+}
+
+// Middleware takes a token for every request except to skip paths, sets
+// the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers and
+// answers 429 with Retry-After when the bucket is empty. If the store
+// fails the request is let through.
+func (l *Limiter) Middleware(skip ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if slices.Contains(skip, c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+		write := c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead
+		limit, res, err := l.Take(c.Request.Context(), l.Client(c), write)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "ratelimit: store failed, request let through", "error", err)
+			c.Next()
+			return
+		}
+		h := c.Writer.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		h.Set("RateLimit-Reset", Seconds(res.Reset))
+		if !res.Allowed {
+			h.Set("Retry-After", Seconds(res.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":      "TooManyRequests",
+				"message":    "Rate limit exceeded, retry in " + Seconds(res.RetryAfter) + "s",
+				"request_id": logging.RequestID(c.Request.Context()),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Seconds rounds d up to whole seconds, as the RateLimit headers carry it.
+func Seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
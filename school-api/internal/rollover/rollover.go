@@ -0,0 +1,199 @@
+// Package rollover implements the end-of-year class promotion job.
+package rollover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/enrollment"
+	"school-api/internal/models"
+)
+
+// DefaultFinalGrade is the last grade of a Russian general school.
+const DefaultFinalGrade = 11
+
+// DefaultYearEnd is the month and day, in the graduation year, on which
+// graduates' enrollments end by default. End dates are exclusive, so the
+// enrollments still cover June, the month of final exams.
+const DefaultYearEnd = "07-01"
+
+var (
+	// ErrAlreadyApplied is returned when a rollover for the year exists.
+	ErrAlreadyApplied = errors.New("rollover already applied for this year")
+	// ErrInvalidOptions is returned for options that cannot be applied.
+	ErrInvalidOptions = errors.New("invalid rollover options")
+
+	errDryRun = errors.New("dry run")
+)
+
+// Options control a rollover run.
+type Options struct {
+	// Year is the academic year that is ending (graduation year).
+	// Defaults to the current calendar year.
+	Year int `json:"year"`
+	// FinalGrade is the grade whose classes graduate. Defaults to 11.
+	FinalGrade int `json:"final_grade"`
+	// EndDate (YYYY-MM-DD, in Year) closes the enrollments of graduating
+	// classes. Defaults to DefaultYearEnd of Year, so the history does not
+	// depend on the day the rollover happens to run.
+	EndDate string `json:"end_date"`
+	// NewFirstGradeLetters lists letters of first-grade classes to create
+	// after promotion, e.g. ["A", "B"].
+	NewFirstGradeLetters []string `json:"new_first_grade_letters"`
+}
+
+// ClassChange describes a promoted class.
+type ClassChange struct {
+	ClassID   uint   `json:"class_id"`
+	Letter    string `json:"letter"`
+	FromGrade int    `json:"from_grade"`
+	ToGrade   int    `json:"to_grade"`
+}
+
+// GraduatedClass describes a class that finished school.
+type GraduatedClass struct {
+	ClassID  uint   `json:"class_id"`
+	Grade    int    `json:"grade"`
+	Letter   string `json:"letter"`
+	Students int64  `json:"students"`
+}
+
+// Summary lists everything a rollover changes.
+type Summary struct {
+	Year              int              `json:"year"`
+	EndDate           string           `json:"end_date"`
+	DryRun            bool             `json:"dry_run"`
+	Promoted          []ClassChange    `json:"promoted"`
+	Graduated         []GraduatedClass `json:"graduated"`
+	GraduatedStudents int64            `json:"graduated_students"`
+	Created           []models.Class   `json:"created"`
+}
+
+// Preview runs the rollover inside a transaction that is always rolled
+// back, so the summary is exactly what Apply would do.
+func Preview(db *gorm.DB, opts Options) (Summary, error) {
+	var summary Summary
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		summary, err = run(tx, opts)
+		if err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if errors.Is(err, errDryRun) {
+		summary.DryRun = true
+		return summary, nil
+	}
+	return summary, err
+}
+
+// Apply runs the rollover in a single transaction and records it.
+func Apply(db *gorm.DB, opts Options) (Summary, error) {
+	var summary Summary
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		summary, err = run(tx, opts)
+		if err != nil {
+			return err
+		}
+		raw, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&models.RolloverRun{Year: summary.Year, Summary: string(raw)}).Error
+	})
+	return summary, err
+}
+
+func run(tx *gorm.DB, opts Options) (Summary, error) {
+	if opts.Year == 0 {
+		opts.Year = time.Now().Year()
+	}
+	if opts.FinalGrade == 0 {
+		opts.FinalGrade = DefaultFinalGrade
+	}
+	if opts.FinalGrade < 1 || opts.FinalGrade > 12 {
+		return Summary{}, fmt.Errorf("%w: final_grade must be between 1 and 12", ErrInvalidOptions)
+	}
+	if opts.EndDate == "" {
+		opts.EndDate = fmt.Sprintf("%d-%s", opts.Year, DefaultYearEnd)
+	}
+	if end, err := time.Parse(enrollment.DateLayout, opts.EndDate); err != nil || end.Year() != opts.Year {
+		return Summary{}, fmt.Errorf("%w: end_date must be a YYYY-MM-DD date in %d", ErrInvalidOptions, opts.Year)
+	}
+	for _, l := range opts.NewFirstGradeLetters {
+		if len(l) != 1 || l[0] < 'A' || l[0] > 'Z' {
+			return Summary{}, fmt.Errorf("%w: letter %q must be a single A-Z character", ErrInvalidOptions, l)
+		}
+	}
+
+	var done int64
+	if err := tx.Model(&models.RolloverRun{}).Where("year = ?", opts.Year).Count(&done).Error; err != nil {
+		return Summary{}, err
+	}
+	if done > 0 {
+		return Summary{}, fmt.Errorf("%w: %d", ErrAlreadyApplied, opts.Year)
+	}
+
+	summary := Summary{
+		Year:      opts.Year,
+		EndDate:   opts.EndDate,
+		Promoted:  []ClassChange{},
+		Graduated: []GraduatedClass{},
+		Created:   []models.Class{},
+	}
+
+	var classes []models.Class
+	if err := tx.Where("graduated_year IS NULL").Order("grade DESC, letter").Find(&classes).Error; err != nil {
+		return Summary{}, err
+	}
+
+	// Highest grades first so each promoted class frees its grade/letter
+	// slot before the class below moves into it.
+	for _, cl := range classes {
+		if cl.Grade >= opts.FinalGrade {
+			res := tx.Model(&models.Student{}).
+				Where("class_id = ? AND status = ?", cl.ID, models.StudentActive).
+				Update("status", models.StudentAlumni)
+			if res.Error != nil {
+				return Summary{}, res.Error
+			}
+			if err := tx.Model(&models.Class{}).Where("id = ?", cl.ID).
+				Update("graduated_year", opts.Year).Error; err != nil {
+				return Summary{}, err
+			}
+			if err := enrollment.CloseClass(tx, cl.ID, opts.EndDate); err != nil {
+				return Summary{}, err
+			}
+			summary.Graduated = append(summary.Graduated, GraduatedClass{
+				ClassID: cl.ID, Grade: cl.Grade, Letter: cl.Letter, Students: res.RowsAffected,
+			})
+			summary.GraduatedStudents += res.RowsAffected
+			continue
+		}
+		if err := tx.Model(&models.Class{}).Where("id = ?", cl.ID).
+			Update("grade", cl.Grade+1).Error; err != nil {
+			return Summary{}, fmt.Errorf("promote class %d%s: %w", cl.Grade, cl.Letter, err)
+		}
+		summary.Promoted = append(summary.Promoted, ClassChange{
+			ClassID: cl.ID, Letter: cl.Letter, FromGrade: cl.Grade, ToGrade: cl.Grade + 1,
+		})
+	}
+
+	letters := append([]string(nil), opts.NewFirstGradeLetters...)
+	sort.Strings(letters)
+	for _, l := range letters {
+		cl := models.Class{Grade: 1, Letter: l}
+		if err := tx.Create(&cl).Error; err != nil {
+			return Summary{}, fmt.Errorf("create class 1%s: %w", l, err)
+		}
+		summary.Created = append(summary.Created, cl)
+	}
+	return summary, nil
+}
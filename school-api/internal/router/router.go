@@ -1,40 +1,196 @@
 package router
 
 import (
+    "net/http"
+    "net/netip"
+    "slices"
+
+    "github.com/getkin/kin-openapi/openapi3"
     "github.com/gin-gonic/gin"
+    "go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
     "gorm.io/gorm"
+    "school-api/internal/apikey"
+    "school-api/internal/auth"
+    "school-api/internal/cache"
+    "school-api/internal/config"
+    "school-api/internal/cors"
+    "school-api/internal/events"
+    "school-api/internal/gql"
     "school-api/internal/handlers"
+    "school-api/internal/logging"
+    "school-api/internal/metrics"
+    "school-api/internal/openapi"
+    "school-api/internal/ratelimit"
+    "school-api/internal/storage"
+    "school-api/internal/stream"
+    "school-api/internal/tenant"
+    "school-api/internal/tracing"
 )
 
-func Setup(db *gorm.DB) *gin.Engine {
-    r := gin.Default()
-    
-    // Add CORS middleware
-    r.Use(func(c *gin.Context) {
-        c.Header("Access-Control-Allow-Origin", "*")
-        c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-        c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
-        c.Header("Access-Control-Allow-Credentials", "true")
-        
-        if c.Request.Method == "OPTIONS" {
-            c.AbortWithStatus(204)
-            return
-        }
-        
-        c.Next()
-    })
-    
-    api := r.Group("/api/v1")
-
-    handlers.ClassHandler{DB: db}.Register(api)
-    handlers.StudentHandler{DB: db}.Register(api)
-    handlers.TeacherHandler{DB: db}.Register(api)
-    handlers.SubjectHandler{DB: db}.Register(api)
-    handlers.TeacherAssignmentHandler{DB: db}.Register(api)
-    handlers.LessonScheduleHandler{DB: db}.Register(api)
-    handlers.LessonLogHandler{DB: db}.Register(api)
-    handlers.StudentLessonHandler{DB: db}.Register(api)
-    handlers.AttendanceStatusHandler{DB: db}.Register(api)
+// Deps are the shared services handlers are built from.
+type Deps struct {
+    DB             *gorm.DB
+    Auth           auth.Authenticator
+    RequireAuth    bool // reject anonymous API calls
+    Files          storage.Store
+    UploadLimits   storage.Limits
+    Events         *events.Bus
+    Stream         *stream.Hub
+    Metrics        *metrics.Metrics // optional; enables /metrics
+    Features       config.Features
+    CORS           config.CORS
+    TrustedProxies []string           // may set X-Forwarded-For; nil trusts none
+    RateLimit      *ratelimit.Limiter // optional
+    Tenant         tenant.Resolver
+    Cache          *cache.Cache // optional
+
+    // WebhookNetworks may receive webhooks besides public addresses.
+    WebhookNetworks []netip.Prefix
+}
+
+// Setup builds the engine with all routes, the health probes, the
+// generated OpenAPI document at /openapi.json and, when Deps.Metrics is
+// set, the Prometheus endpoint at /metrics.
+func Setup(d Deps) *gin.Engine {
+    r, doc := build(d)
+    r.GET("/openapi.json", func(c *gin.Context) { c.JSON(http.StatusOK, doc) })
+    handlers.HealthHandler{DB: d.DB}.Register(&r.RouterGroup)
+    if d.Metrics != nil {
+        r.GET("/metrics", gin.WrapH(d.Metrics.Handler()))
+    }
     return r
 }
 
+// OpenAPI returns the OpenAPI document of the API with every feature
+// enabled.
+func OpenAPI() (*openapi3.T, error) {
+    r := gin.New()
+    register(r, Deps{Features: config.Default().Features}, nil)
+    return spec(r, operations())
+}
+
+func build(d Deps) (*gin.Engine, *openapi3.T) {
+    r := gin.New()
+    r.RemoteIPHeaders = []string{"X-Forwarded-For"}
+    if err := r.SetTrustedProxies(d.TrustedProxies); err != nil {
+        panic(err)
+    }
+    r.Use(otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(traced)))
+    r.Use(logging.Middleware(quietPaths...), logging.Recovery())
+    if d.Metrics != nil {
+        r.Use(d.Metrics.Middleware())
+    }
+
+    r.Use(cors.Middleware(d.CORS))
+    if d.RateLimit != nil {
+        r.Use(d.RateLimit.Middleware(quietPaths...))
+    }
+
+    validator := &openapi.Validator{}
+    var validate gin.HandlerFunc
+    if d.Features.RequestValidation {
+        validate = validator.Middleware()
+    }
+    register(r, d, validate)
+    doc, err := spec(r, enabled(r, operations()))
+    if err != nil {
+        panic(err)
+    }
+    validator.Load(doc)
+    return r, doc
+}
+
+// operations returns the documented operations with their full paths.
+func operations() []openapi.Operation {
+    ops := handlers.Operations()
+    for i := range ops {
+        ops[i].Path = apiPrefix + ops[i].Path
+    }
+    return ops
+}
+
+func spec(r *gin.Engine, ops []openapi.Operation) (*openapi3.T, error) {
+    return openapi.Build(openapi.Info{Title: "School Management API", Version: "1.0.0"}, r.Routes(), ops)
+}
+
+// enabled drops the operations of routes switched off by a feature toggle,
+// so that the served document matches the running server.
+func enabled(r *gin.Engine, ops []openapi.Operation) []openapi.Operation {
+    routes := map[string]bool{}
+    for _, ri := range r.Routes() {
+        routes[ri.Method+" "+ri.Path] = true
+    }
+    var out []openapi.Operation
+    for _, op := range ops {
+        if routes[op.Method+" "+op.Path] {
+            out = append(out, op)
+        }
+    }
+    return out
+}
+
+const apiPrefix = "/api/v1"
+
+// quietPaths are polled by the platform and kept out of the request log
+// and traces.
+var quietPaths = []string{"/healthz", "/readyz", "/version", "/metrics"}
+
+func traced(r *http.Request) bool {
+    return !slices.Contains(quietPaths, r.URL.Path)
+}
+
+// register adds the API routes; validate, when set, checks requests
+// against the OpenAPI document.
+func register(r *gin.Engine, d Deps, validate gin.HandlerFunc) {
+    db := d.DB
+    api := r.Group(apiPrefix, d.Auth.Middleware(d.RequireAuth), auth.RequireScope(apikey.ScopeFor), d.Tenant.Middleware())
+    if validate != nil {
+        api.Use(validate)
+    }
+
+    // Journal API: staff and API keys; guardians are kept to /me
+    staff := api.Group("", auth.RestrictRoles(auth.StaffRoles...))
+    handlers.SchoolHandler{DB: db}.Register(staff)
+    handlers.ClassHandler{DB: db, Cache: d.Cache}.Register(staff)
+    handlers.StudentHandler{DB: db}.Register(staff)
+    handlers.TeacherHandler{DB: db}.Register(staff)
+    handlers.SubjectHandler{DB: db, Cache: d.Cache}.Register(staff)
+    handlers.TeacherAssignmentHandler{DB: db}.Register(staff)
+    handlers.LessonScheduleHandler{DB: db, Events: d.Events}.Register(staff)
+    handlers.LessonLogHandler{DB: db, Events: d.Events}.Register(staff)
+    handlers.StudentLessonHandler{DB: db, Events: d.Events}.Register(staff)
+    handlers.AttendanceStatusHandler{DB: db, Cache: d.Cache}.Register(staff)
+    handlers.DuplicateHandler{DB: db}.Register(staff)
+    handlers.RolloverHandler{DB: db, Cache: d.Cache}.Register(staff)
+    handlers.GuardianHandler{DB: db}.Register(staff)
+    handlers.HomeworkHandler{DB: db}.Register(staff)
+    handlers.SubmissionHandler{DB: db, Store: d.Files, Limits: d.UploadLimits, Events: d.Events}.Register(staff)
+    if d.Features.Webhooks {
+        handlers.WebhookHandler{DB: db, AllowedNetworks: d.WebhookNetworks}.Register(staff)
+    }
+    if d.Features.LiveUpdates {
+        handlers.StreamHandler{Hub: d.Stream}.Register(staff)
+    }
+    handlers.SearchHandler{DB: db}.Register(staff)
+    if d.Features.GraphQL {
+        handlers.GraphQLHandler{DB: db, Schema: gql.NewSchema(db)}.Register(staff)
+    }
+
+    // Parent portal: only a guardian's own children are visible
+    if d.Features.ParentPortal {
+        me := api.Group("/me", d.Auth.Middleware(true), auth.RequireRole(auth.RoleGuardian))
+        handlers.ParentHandler{DB: db}.Register(me)
+    }
+
+    // API key management: admins only
+    admin := api.Group("", d.Auth.Middleware(true), auth.RequireRole(auth.RoleAdmin))
+    handlers.APIKeyHandler{DB: db}.Register(admin)
+
+    // Data shared by all schools: operators only, outside any school
+    operator := r.Group(apiPrefix, d.Auth.Middleware(true), auth.RequireRole(auth.RoleOperator))
+    if validate != nil {
+        operator.Use(validate)
+    }
+    handlers.AttendanceStatusHandler{DB: db, Cache: d.Cache}.RegisterWrites(operator)
+}
+
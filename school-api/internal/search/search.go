@@ -0,0 +1,188 @@
+// Package search finds students, teachers and subjects by name using
+// Postgres full-text search (Russian stemming) and trigram similarity
+// (pg_trgm) for typos.
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/tenant"
+)
+
+// Result types.
+const (
+	TypeStudent = "student"
+	TypeTeacher = "teacher"
+	TypeSubject = "subject"
+)
+
+// Types lists every searchable type.
+var Types = []string{TypeStudent, TypeTeacher, TypeSubject}
+
+// Result is one ranked match.
+type Result struct {
+	Type    string  `json:"type"`
+	ID      uint    `json:"id"`
+	Title   string  `json:"title"`
+	ClassID *uint   `json:"class_id,omitempty"`
+	Score   float64 `json:"score"`
+}
+
+// personVector is the indexed full-text expression for people; it must
+// match the expression of the GIN indexes created by the migration.
+const personVector = `to_tsvector('russian', coalesce(last_name, '') || ' ' || coalesce(first_name, '') || ' ' || coalesce(patronymic, ''))`
+
+// subjectVector is the indexed full-text expression for subjects.
+const subjectVector = `to_tsvector('russian', coalesce(subject_name, ''))`
+
+// Query is a parsed search string. For "Иванов И.П." Surname is "Иванов"
+// and Initials are ["И", "П"] (first name, patronymic).
+type Query struct {
+	Text     string
+	Surname  string
+	Initials []string
+}
+
+// Parse splits raw into name words and initials. A token made only of
+// single letters separated by dots ("И", "И.", "И.П.") counts as initials.
+func Parse(raw string) Query {
+	var words, initials []string
+	for _, tok := range strings.Fields(raw) {
+		if in := initialsOf(tok); in != nil {
+			initials = append(initials, in...)
+			continue
+		}
+		words = append(words, strings.Trim(tok, ".,"))
+	}
+	q := Query{Text: strings.Join(words, " "), Initials: initials}
+	if len(words) > 0 {
+		q.Surname = words[0]
+	}
+	if len(q.Initials) > 2 {
+		q.Initials = q.Initials[:2]
+	}
+	return q
+}
+
+func initialsOf(tok string) []string {
+	var out []string
+	for _, part := range strings.Split(tok, ".") {
+		if part == "" {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(part)
+		if size != len(part) || !unicode.IsLetter(r) {
+			return nil
+		}
+		out = append(out, string(unicode.ToUpper(r)))
+	}
+	return out
+}
+
+// Search returns up to limit results across types, best first.
+func Search(db *gorm.DB, raw string, types []string, limit int) ([]Result, error) {
+	q := Parse(raw)
+	if q.Text == "" && len(q.Initials) == 0 {
+		return []Result{}, nil
+	}
+	results := []Result{}
+	for _, t := range types {
+		var rows []Result
+		var err error
+		switch t {
+		case TypeStudent:
+			rows, err = people(db, "students", TypeStudent, q, limit)
+		case TypeTeacher:
+			rows, err = people(db, "teachers", TypeTeacher, q, limit)
+		case TypeSubject:
+			rows, err = subjects(db, q, limit)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rows...)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func people(db *gorm.DB, table, typ string, q Query, limit int) ([]Result, error) {
+	classCol := "NULL::bigint"
+	if table == "students" {
+		classCol = "class_id"
+	}
+	args := map[string]any{
+		"type":    typ,
+		"text":    q.Text,
+		"surname": q.Surname,
+		"limit":   limit,
+	}
+	var where []string
+	if q.Text != "" {
+		where = append(where, `(`+personVector+` @@ plainto_tsquery('russian', @text)
+			OR last_name % @surname
+			OR (last_name || ' ' || first_name) % @text)`)
+	}
+	if len(q.Initials) > 0 {
+		where = append(where, "upper(left(first_name, 1)) = @first")
+		args["first"] = q.Initials[0]
+	}
+	if len(q.Initials) > 1 {
+		where = append(where, "upper(left(patronymic, 1)) = @middle")
+		args["middle"] = q.Initials[1]
+	}
+	where = inSchool(db, where, args)
+	sql := `SELECT CAST(@type AS text) AS type, id,
+		trim(last_name || ' ' || first_name || ' ' || coalesce(patronymic, '')) AS title,
+		` + classCol + ` AS class_id,
+		GREATEST(
+			ts_rank(` + personVector + `, plainto_tsquery('russian', @text)),
+			similarity(last_name, @surname),
+			similarity(last_name || ' ' || first_name, @text)
+		) AS score
+		FROM ` + table + `
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY score DESC, last_name, first_name
+		LIMIT @limit`
+	var rows []Result
+	err := db.Raw(sql, args).Scan(&rows).Error
+	return rows, err
+}
+
+func subjects(db *gorm.DB, q Query, limit int) ([]Result, error) {
+	var rows []Result
+	if q.Text == "" {
+		return rows, nil
+	}
+	args := map[string]any{"type": TypeSubject, "text": q.Text, "limit": limit}
+	where := inSchool(db, []string{`(` + subjectVector + ` @@ plainto_tsquery('russian', @text) OR subject_name % @text)`}, args)
+	sql := `SELECT CAST(@type AS text) AS type, id, subject_name AS title,
+		GREATEST(
+			ts_rank(` + subjectVector + `, plainto_tsquery('russian', @text)),
+			similarity(subject_name, @text)
+		) AS score
+		FROM subjects
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY score DESC, subject_name
+		LIMIT @limit`
+	err := db.Raw(sql, args).Scan(&rows).Error
+	return rows, err
+}
+
+// inSchool adds the school of db's context to the conditions of a raw
+// query, which the tenant plugin does not rewrite.
+func inSchool(db *gorm.DB, where []string, args map[string]any) []string {
+	if id, ok := tenant.FromContext(db.Statement.Context); ok {
+		where = append(where, "school_id = @school")
+		args["school"] = id
+	}
+	return where
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/models"
+)
+
+// AttendanceStatuses is keyed by code rather than a numeric ID.
+type AttendanceStatuses struct{ DB *gorm.DB }
+
+func (s AttendanceStatuses) List(ctx context.Context) ([]models.AttendanceStatus, error) {
+	return list[models.AttendanceStatus](s.DB.WithContext(ctx), nil)
+}
+
+func (s AttendanceStatuses) Get(ctx context.Context, code string) (models.AttendanceStatus, error) {
+	return find[models.AttendanceStatus](ctx, s.DB, nil, "code = ?", code)
+}
+
+func (s AttendanceStatuses) Create(ctx context.Context, input models.AttendanceStatus) (models.AttendanceStatus, error) {
+	err := create(ctx, s.DB, &input, nil)
+	return input, err
+}
+
+func (s AttendanceStatuses) Update(ctx context.Context, code string, input models.AttendanceStatus) (models.AttendanceStatus, error) {
+	item, err := s.Get(ctx, code)
+	if err != nil {
+		return item, err
+	}
+	item.Description = input.Description
+	err = save(ctx, s.DB, &item, nil)
+	return item, err
+}
+
+func (s AttendanceStatuses) Delete(ctx context.Context, code string) error {
+	return s.DB.WithContext(ctx).Delete(&models.AttendanceStatus{}, "code = ?", code).Error
+}
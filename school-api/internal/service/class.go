@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/enrollment"
+	"school-api/internal/models"
+)
+
+type Classes struct{ DB *gorm.DB }
+
+// classConflict matches another active class with the same grade and letter.
+func classConflict(db *gorm.DB, item *models.Class) (uint, error) {
+	return conflictingID(db, &models.Class{}, item.ID, "grade = ? AND letter = ? AND graduated_year IS NULL", item.Grade, item.Letter)
+}
+
+func (s Classes) List(ctx context.Context) ([]models.Class, error) {
+	return list[models.Class](s.DB.WithContext(ctx), nil)
+}
+
+func (s Classes) Get(ctx context.Context, id uint) (models.Class, error) {
+	return find[models.Class](ctx, s.DB, nil, id)
+}
+
+func (s Classes) Create(ctx context.Context, input models.Class) (models.Class, error) {
+	input.ID = 0
+	err := create(ctx, s.DB, &input, classConflict)
+	return input, err
+}
+
+func (s Classes) Update(ctx context.Context, id uint, input models.Class) (models.Class, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+	item.Grade = input.Grade
+	item.Letter = input.Letter
+	err = save(ctx, s.DB, &item, classConflict)
+	return item, err
+}
+
+// Delete removes a class. A class still referenced by students, their
+// enrollments or the timetable is refused with an InvalidError.
+func (s Classes) Delete(ctx context.Context, id uint) error {
+	return Invalid(s.DB.WithContext(ctx).Delete(&models.Class{}, id).Error)
+}
+
+// Students returns the class roster as of date, based on enrollment
+// history rather than the current class_id.
+func (s Classes) Students(ctx context.Context, id uint, date string) ([]models.Student, error) {
+	return list[models.Student](s.DB.WithContext(ctx).
+		Scopes(enrollment.StudentsInClassOn(id, date)).Order("last_name, first_name"), nil)
+}
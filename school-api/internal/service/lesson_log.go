@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/events"
+	"school-api/internal/models"
+)
+
+type LessonLogs struct {
+	DB     *gorm.DB
+	Events *events.Bus
+}
+
+// LessonLogFilter narrows LessonLogs.Each. From and To are inclusive
+// YYYY-MM-DD dates; zero values do not filter.
+type LessonLogFilter struct {
+	ClassID uint
+	From    string
+	To      string
+}
+
+// lessonLogConflict matches another lesson of the same class in the same
+// slot of the same day.
+func lessonLogConflict(db *gorm.DB, item *models.LessonLog) (uint, error) {
+	return conflictingID(db, &models.LessonLog{}, item.ID, "class_id = ? AND date = ? AND number = ?", item.ClassID, item.Date, item.Number)
+}
+
+func (s LessonLogs) List(ctx context.Context, preload ...string) ([]models.LessonLog, error) {
+	return list[models.LessonLog](s.DB.WithContext(ctx), preload)
+}
+
+// Each calls fn for every lesson matching f in batches of batchSize,
+// ordered by ID, so large ranges are never held in memory at once.
+func (s LessonLogs) Each(ctx context.Context, f LessonLogFilter, batchSize int, fn func([]models.LessonLog) error) error {
+	q := s.DB.WithContext(ctx).Model(&models.LessonLog{})
+	if f.ClassID != 0 {
+		q = q.Where("class_id = ?", f.ClassID)
+	}
+	if f.From != "" {
+		q = q.Where("date >= ?", f.From)
+	}
+	if f.To != "" {
+		q = q.Where("date <= ?", f.To)
+	}
+	var batch []models.LessonLog
+	return q.FindInBatches(&batch, batchSize, func(*gorm.DB, int) error {
+		return fn(batch)
+	}).Error
+}
+
+func (s LessonLogs) Get(ctx context.Context, id uint, preload ...string) (models.LessonLog, error) {
+	return find[models.LessonLog](ctx, s.DB, preload, id)
+}
+
+func (s LessonLogs) Create(ctx context.Context, input models.LessonLog) (models.LessonLog, error) {
+	input.ID = 0
+	if err := create(ctx, s.DB, &input, lessonLogConflict); err != nil {
+		return input, err
+	}
+	s.Events.Publish(ctx, events.New(events.LessonLogCreated, input))
+	return input, nil
+}
+
+func (s LessonLogs) Update(ctx context.Context, id uint, input models.LessonLog) (models.LessonLog, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+	item.SubjectID = input.SubjectID
+	item.Date = input.Date
+	item.Number = input.Number
+	item.ClassID = input.ClassID
+	item.TeacherID = input.TeacherID
+	if err := save(ctx, s.DB, &item, lessonLogConflict); err != nil {
+		return item, err
+	}
+	s.Events.Publish(ctx, events.New(events.LessonLogUpdated, item))
+	return item, nil
+}
+
+// Delete removes the lesson; deleting a missing lesson is not an error.
+func (s LessonLogs) Delete(ctx context.Context, id uint) error {
+	item, err := s.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.DB.WithContext(ctx).Delete(&item).Error; err != nil {
+		return err
+	}
+	s.Events.Publish(ctx, events.New(events.LessonLogDeleted, item))
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/events"
+	"school-api/internal/models"
+)
+
+type LessonSchedules struct {
+	DB     *gorm.DB
+	Events *events.Bus
+}
+
+func (s LessonSchedules) List(ctx context.Context) ([]models.LessonSchedule, error) {
+	return list[models.LessonSchedule](s.DB.WithContext(ctx), nil)
+}
+
+func (s LessonSchedules) Get(ctx context.Context, id uint) (models.LessonSchedule, error) {
+	return find[models.LessonSchedule](ctx, s.DB, nil, id)
+}
+
+func (s LessonSchedules) Create(ctx context.Context, input models.LessonSchedule) (models.LessonSchedule, error) {
+	input.ID = 0
+	if err := create(ctx, s.DB, &input, nil); err != nil {
+		return input, err
+	}
+	s.Events.Publish(ctx, events.New(events.LessonScheduleCreated, input))
+	return input, nil
+}
+
+func (s LessonSchedules) Update(ctx context.Context, id uint, input models.LessonSchedule) (models.LessonSchedule, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+	item.SubjectID = input.SubjectID
+	item.Weekday = input.Weekday
+	item.Number = input.Number
+	item.ClassID = input.ClassID
+	item.TeacherID = input.TeacherID
+	if err := save(ctx, s.DB, &item, nil); err != nil {
+		return item, err
+	}
+	s.Events.Publish(ctx, events.New(events.LessonScheduleUpdated, item))
+	return item, nil
+}
+
+// Delete removes the entry; deleting a missing entry is not an error.
+func (s LessonSchedules) Delete(ctx context.Context, id uint) error {
+	item, err := s.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.DB.WithContext(ctx).Delete(&item).Error; err != nil {
+		return err
+	}
+	s.Events.Publish(ctx, events.New(events.LessonScheduleDeleted, item))
+	return nil
+}
@@ -0,0 +1,196 @@
+// Package service holds the create/read/update/delete logic for the journal
+// entities, shared by the REST handlers and the gRPC server so both apply
+// the same natural-key checks and publish the same events.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNotFound is returned when the requested row does not exist.
+var ErrNotFound = errors.New("resource not found")
+
+// ConflictError is returned when a write would duplicate the natural key of
+// an existing row.
+type ConflictError struct {
+	ExistingID uint
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("resource with the same natural key already exists (id %d)", e.ExistingID)
+}
+
+// InvalidError is returned when a write is rejected because of the
+// request data: a domain rule, or a Postgres data or integrity constraint.
+type InvalidError struct {
+	Err error
+}
+
+func (e *InvalidError) Error() string { return e.Err.Error() }
+func (e *InvalidError) Unwrap() error { return e.Err }
+
+// keyPattern extracts the column from a Postgres error detail such as
+// `Key (subject_id)=(42) is not present in table "subjects".`
+var keyPattern = regexp.MustCompile(`^Key \(([^)]+)\)`)
+
+// Message describes the rejection for clients. Postgres errors are
+// rephrased so that SQL, table and constraint names stay in the server log.
+func (e *InvalidError) Message() string {
+	var pgErr *pgconn.PgError
+	if !errors.As(e.Err, &pgErr) {
+		return e.Err.Error()
+	}
+	column := pgErr.ColumnName
+	if m := keyPattern.FindStringSubmatch(pgErr.Detail); m != nil {
+		column = m[1]
+	}
+	switch {
+	case pgErr.Code == "23502":
+		return column + " is required"
+	case pgErr.Code == "23503" && strings.Contains(pgErr.Detail, "still referenced"):
+		return "resource is still referenced by other records"
+	case pgErr.Code == "23503":
+		return column + " refers to a resource that does not exist"
+	case pgErr.Code == "23505":
+		return column + " must be unique"
+	case pgErr.Code == "23514":
+		return "a value is out of the allowed range"
+	default:
+		return "invalid value"
+	}
+}
+
+// Invalid wraps err in an InvalidError when the request data caused it,
+// and returns other errors (lost connections, timeouts, bugs) unchanged so
+// they surface as internal errors. A nil err stays nil.
+func Invalid(err error) error {
+	if err == nil {
+		return nil
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 22 is data exception, class 23 integrity constraint violation.
+		if strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23") {
+			return &InvalidError{Err: err}
+		}
+		return err
+	}
+	if isInfrastructure(err) {
+		return err
+	}
+	return &InvalidError{Err: err}
+}
+
+// isInfrastructure reports whether err comes from the connection to the
+// database rather than from a rule about the data.
+func isInfrastructure(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || pgconn.Timeout(err) || pgconn.SafeToRetry(err) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, gorm.ErrInvalidDB) || errors.Is(err, gorm.ErrInvalidTransaction)
+}
+
+// conflictFunc returns the ID of another row with the same natural key as
+// item, or 0 if there is none.
+type conflictFunc[M any] func(db *gorm.DB, item *M) (uint, error)
+
+// conflictingID returns the ID of an existing row of model, other than
+// excludeID, that matches the natural-key condition, or 0 if there is none.
+func conflictingID(db *gorm.DB, model any, excludeID uint, query string, args ...any) (uint, error) {
+	var ids []uint
+	err := db.Model(model).Where(query, args...).Where("id <> ?", excludeID).
+		Order("id").Limit(1).Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique_violation,
+// raised when a concurrent request wins the race past the pre-check.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// find loads the row with the given primary key, preloading the given
+// association paths.
+func find[M any](ctx context.Context, db *gorm.DB, preload []string, conds ...any) (M, error) {
+	var item M
+	q := db.WithContext(ctx)
+	for _, p := range preload {
+		q = q.Preload(p)
+	}
+	err := q.First(&item, conds...).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return item, ErrNotFound
+	}
+	return item, err
+}
+
+// list loads all rows matching q, preloading the given association paths.
+func list[M any](q *gorm.DB, preload []string) ([]M, error) {
+	for _, p := range preload {
+		q = q.Preload(p)
+	}
+	var items []M
+	err := q.Find(&items).Error
+	return items, err
+}
+
+// create inserts item unless it would duplicate a natural key.
+func create[M any](ctx context.Context, db *gorm.DB, item *M, conflict conflictFunc[M]) error {
+	return write(ctx, db, item, conflict, func(tx *gorm.DB) error {
+		return tx.Omit(clause.Associations).Create(item).Error
+	})
+}
+
+// save updates item unless it would duplicate a natural key.
+func save[M any](ctx context.Context, db *gorm.DB, item *M, conflict conflictFunc[M]) error {
+	return write(ctx, db, item, conflict, func(tx *gorm.DB) error {
+		return tx.Omit(clause.Associations).Save(item).Error
+	})
+}
+
+// write runs op after checking the natural key, and checks it again when
+// op hits the unique index so the caller gets a ConflictError either way.
+func write[M any](ctx context.Context, db *gorm.DB, item *M, conflict conflictFunc[M], op func(*gorm.DB) error) error {
+	db = db.WithContext(ctx)
+	if conflict != nil {
+		if err := checkConflict(db, item, conflict); err != nil {
+			return err
+		}
+	}
+	if err := op(db); err != nil {
+		if conflict != nil && isUniqueViolation(err) {
+			if err := checkConflict(db, item, conflict); err != nil {
+				return err
+			}
+		}
+		return Invalid(err)
+	}
+	return nil
+}
+
+func checkConflict[M any](db *gorm.DB, item *M, conflict conflictFunc[M]) error {
+	id, err := conflict(db, item)
+	if err != nil {
+		return err
+	}
+	if id != 0 {
+		return &ConflictError{ExistingID: id}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/enrollment"
+	"school-api/internal/models"
+)
+
+type Students struct{ DB *gorm.DB }
+
+// StudentFilter narrows Students.List. With ClassID set the result is the
+// class roster as of Date according to enrollment history.
+type StudentFilter struct {
+	ClassID *uint
+	Date    string
+}
+
+func (s Students) List(ctx context.Context, f StudentFilter) ([]models.Student, error) {
+	q := s.DB.WithContext(ctx)
+	if f.ClassID != nil {
+		date := f.Date
+		if date == "" {
+			date = enrollment.Today()
+		}
+		q = q.Scopes(enrollment.StudentsInClassOn(*f.ClassID, date))
+	}
+	return list[models.Student](q, nil)
+}
+
+func (s Students) Get(ctx context.Context, id uint) (models.Student, error) {
+	return find[models.Student](ctx, s.DB, nil, id)
+}
+
+// Create adds an active student and opens their first enrollment.
+func (s Students) Create(ctx context.Context, input models.Student) (models.Student, error) {
+	input.ID = 0
+	input.Status = models.StudentActive
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := enrollment.OpenClass(tx, input.ClassID); err != nil {
+			return err
+		}
+		if err := tx.Create(&input).Error; err != nil {
+			return err
+		}
+		return enrollment.Open(tx, input, enrollment.Today(), "enrolled")
+	})
+	if err != nil {
+		return input, Invalid(err)
+	}
+	return input, nil
+}
+
+// Update changes a student's details. A changed class is recorded as a
+// transfer effective today.
+func (s Students) Update(ctx context.Context, id uint, input models.Student) (models.Student, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+	item.FirstName = input.FirstName
+	item.LastName = input.LastName
+	item.Patronymic = input.Patronymic
+	err = s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if input.ClassID != item.ClassID {
+			if _, err := enrollment.Transfer(tx, &item, input.ClassID, enrollment.Today(), "updated via PUT"); err != nil {
+				return err
+			}
+		}
+		return tx.Save(&item).Error
+	})
+	if err != nil {
+		return item, Invalid(err)
+	}
+	return item, nil
+}
+
+func (s Students) Delete(ctx context.Context, id uint) error {
+	return s.DB.WithContext(ctx).Delete(&models.Student{}, id).Error
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/events"
+	"school-api/internal/models"
+)
+
+type StudentLessons struct {
+	DB     *gorm.DB
+	Events *events.Bus
+}
+
+// StudentLessonFilter narrows StudentLessons.Each. From and To are
+// inclusive YYYY-MM-DD lesson dates; zero values do not filter.
+type StudentLessonFilter struct {
+	StudentID uint
+	ClassID   uint
+	From      string
+	To        string
+}
+
+// studentLessonConflict matches another entry for the same student and
+// lesson.
+func studentLessonConflict(db *gorm.DB, item *models.StudentLesson) (uint, error) {
+	return conflictingID(db, &models.StudentLesson{}, item.ID, "student_id = ? AND lesson_id = ?", item.StudentID, item.LessonID)
+}
+
+func (s StudentLessons) List(ctx context.Context, preload ...string) ([]models.StudentLesson, error) {
+	return list[models.StudentLesson](s.DB.WithContext(ctx), preload)
+}
+
+// Each calls fn for every entry matching f in batches of batchSize,
+// ordered by ID, so large ranges are never held in memory at once.
+func (s StudentLessons) Each(ctx context.Context, f StudentLessonFilter, batchSize int, fn func([]models.StudentLesson) error) error {
+	q := s.DB.WithContext(ctx).Model(&models.StudentLesson{})
+	if f.StudentID != 0 {
+		q = q.Where("student_lessons.student_id = ?", f.StudentID)
+	}
+	if f.ClassID != 0 || f.From != "" || f.To != "" {
+		lessons := s.DB.Model(&models.LessonLog{}).Select("id")
+		if f.ClassID != 0 {
+			lessons = lessons.Where("class_id = ?", f.ClassID)
+		}
+		if f.From != "" {
+			lessons = lessons.Where("date >= ?", f.From)
+		}
+		if f.To != "" {
+			lessons = lessons.Where("date <= ?", f.To)
+		}
+		q = q.Where("student_lessons.lesson_id IN (?)", lessons)
+	}
+	var batch []models.StudentLesson
+	return q.FindInBatches(&batch, batchSize, func(*gorm.DB, int) error {
+		return fn(batch)
+	}).Error
+}
+
+func (s StudentLessons) Get(ctx context.Context, id uint, preload ...string) (models.StudentLesson, error) {
+	return find[models.StudentLesson](ctx, s.DB, preload, id)
+}
+
+func (s StudentLessons) Create(ctx context.Context, input models.StudentLesson) (models.StudentLesson, error) {
+	input.ID = 0
+	if err := create(ctx, s.DB, &input, studentLessonConflict); err != nil {
+		return input, err
+	}
+	s.Publish(ctx, events.StudentLessonCreated, input, nil)
+	return input, nil
+}
+
+func (s StudentLessons) Update(ctx context.Context, id uint, input models.StudentLesson) (models.StudentLesson, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+	before := item
+	item.StudentID = input.StudentID
+	item.LessonID = input.LessonID
+	item.Grade = input.Grade
+	item.AttendanceStatus = input.AttendanceStatus
+	if err := save(ctx, s.DB, &item, studentLessonConflict); err != nil {
+		return item, err
+	}
+	s.Publish(ctx, events.StudentLessonUpdated, item, &before)
+	return item, nil
+}
+
+// Delete removes the entry; deleting a missing entry is not an error.
+func (s StudentLessons) Delete(ctx context.Context, id uint) error {
+	item, err := s.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.DB.WithContext(ctx).Delete(&item).Error; err != nil {
+		return err
+	}
+	s.Publish(ctx, events.StudentLessonDeleted, item, nil)
+	return nil
+}
+
+// Publish raises typ for item and, when the grade or absence is new
+// compared to before (nil on create), the derived grade.recorded /
+// absence.recorded events.
+func (s StudentLessons) Publish(ctx context.Context, typ string, item models.StudentLesson, before *models.StudentLesson) {
+	if s.Events == nil {
+		return
+	}
+	data := events.StudentLessonData{StudentLesson: item}
+	s.DB.WithContext(ctx).Model(&models.LessonLog{}).Where("id = ?", item.LessonID).Pluck("class_id", &data.ClassID)
+	if before != nil {
+		data.PreviousGrade = before.Grade
+		data.PreviousAttendanceStatus = before.AttendanceStatus
+	}
+	evs := []events.Event{events.New(typ, data)}
+	if typ != events.StudentLessonDeleted {
+		if item.Grade != nil && (before == nil || before.Grade == nil || *before.Grade != *item.Grade) {
+			evs = append(evs, events.New(events.GradeRecorded, data))
+		}
+		if item.AttendanceStatus == models.AttendanceAbsent && (before == nil || before.AttendanceStatus != models.AttendanceAbsent) {
+			evs = append(evs, events.New(events.AbsenceRecorded, data))
+		}
+	}
+	s.Events.Publish(ctx, evs...)
+}
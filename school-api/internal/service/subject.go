@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/models"
+)
+
+type Subjects struct{ DB *gorm.DB }
+
+func (s Subjects) List(ctx context.Context) ([]models.Subject, error) {
+	return list[models.Subject](s.DB.WithContext(ctx), nil)
+}
+
+func (s Subjects) Get(ctx context.Context, id uint) (models.Subject, error) {
+	return find[models.Subject](ctx, s.DB, nil, id)
+}
+
+func (s Subjects) Create(ctx context.Context, input models.Subject) (models.Subject, error) {
+	input.ID = 0
+	err := create(ctx, s.DB, &input, nil)
+	return input, err
+}
+
+func (s Subjects) Update(ctx context.Context, id uint, input models.Subject) (models.Subject, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+	item.SubjectName = input.SubjectName
+	err = save(ctx, s.DB, &item, nil)
+	return item, err
+}
+
+func (s Subjects) Delete(ctx context.Context, id uint) error {
+	return s.DB.WithContext(ctx).Delete(&models.Subject{}, id).Error
+}
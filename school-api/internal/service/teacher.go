@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/models"
+)
+
+type Teachers struct{ DB *gorm.DB }
+
+func (s Teachers) List(ctx context.Context) ([]models.Teacher, error) {
+	return list[models.Teacher](s.DB.WithContext(ctx), nil)
+}
+
+func (s Teachers) Get(ctx context.Context, id uint) (models.Teacher, error) {
+	return find[models.Teacher](ctx, s.DB, nil, id)
+}
+
+func (s Teachers) Create(ctx context.Context, input models.Teacher) (models.Teacher, error) {
+	input.ID = 0
+	err := create(ctx, s.DB, &input, nil)
+	return input, err
+}
+
+func (s Teachers) Update(ctx context.Context, id uint, input models.Teacher) (models.Teacher, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+	item.FirstName = input.FirstName
+	item.LastName = input.LastName
+	item.Patronymic = input.Patronymic
+	err = save(ctx, s.DB, &item, nil)
+	return item, err
+}
+
+func (s Teachers) Delete(ctx context.Context, id uint) error {
+	return s.DB.WithContext(ctx).Delete(&models.Teacher{}, id).Error
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"school-api/internal/models"
+)
+
+type TeacherAssignments struct{ DB *gorm.DB }
+
+// teacherAssignmentConflict matches another assignment of the same teacher
+// to the same subject.
+func teacherAssignmentConflict(db *gorm.DB, item *models.TeacherAssignment) (uint, error) {
+	return conflictingID(db, &models.TeacherAssignment{}, item.ID, "teacher_id = ? AND subject_id = ?", item.TeacherID, item.SubjectID)
+}
+
+func (s TeacherAssignments) List(ctx context.Context) ([]models.TeacherAssignment, error) {
+	return list[models.TeacherAssignment](s.DB.WithContext(ctx), nil)
+}
+
+func (s TeacherAssignments) Get(ctx context.Context, id uint) (models.TeacherAssignment, error) {
+	return find[models.TeacherAssignment](ctx, s.DB, nil, id)
+}
+
+func (s TeacherAssignments) Create(ctx context.Context, input models.TeacherAssignment) (models.TeacherAssignment, error) {
+	input.ID = 0
+	err := create(ctx, s.DB, &input, teacherAssignmentConflict)
+	return input, err
+}
+
+func (s TeacherAssignments) Update(ctx context.Context, id uint, input models.TeacherAssignment) (models.TeacherAssignment, error) {
+	item, err := s.Get(ctx, id)
+	if err != nil {
+		return item, err
+	}
+	item.TeacherID = input.TeacherID
+	item.SubjectID = input.SubjectID
+	err = save(ctx, s.DB, &item, teacherAssignmentConflict)
+	return item, err
+}
+
+func (s TeacherAssignments) Delete(ctx context.Context, id uint) error {
+	return s.DB.WithContext(ctx).Delete(&models.TeacherAssignment{}, id).Error
+}
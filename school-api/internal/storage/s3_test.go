@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// TestS3 runs against an S3-compatible server, e.g. a local MinIO:
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//	TEST_S3_ENDPOINT=localhost:9000 go test ./internal/storage
+//
+// TEST_S3_ACCESS_KEY and TEST_S3_SECRET_KEY default to MinIO's
+// minioadmin. The test creates and removes its own bucket.
+func TestS3(t *testing.T) {
+	endpoint := os.Getenv("TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("TEST_S3_ENDPOINT is not set")
+	}
+	cfg := S3Config{
+		Endpoint:  endpoint,
+		AccessKey: envOr("TEST_S3_ACCESS_KEY", "minioadmin"),
+		SecretKey: envOr("TEST_S3_SECRET_KEY", "minioadmin"),
+		Bucket:    fmt.Sprintf("storage-test-%d", time.Now().UnixNano()),
+		UseSSL:    os.Getenv("TEST_S3_USE_SSL") == "true",
+	}
+	s, err := NewS3(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := s.client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{}); err != nil {
+		t.Fatalf("create bucket: %v", err)
+	}
+	t.Cleanup(func() {
+		for obj := range s.client.ListObjects(ctx, cfg.Bucket, minio.ListObjectsOptions{Recursive: true}) {
+			s.client.RemoveObject(ctx, cfg.Bucket, obj.Key, minio.RemoveObjectOptions{})
+		}
+		if err := s.client.RemoveBucket(ctx, cfg.Bucket); err != nil {
+			t.Logf("remove bucket: %v", err)
+		}
+	})
+
+	testStore(t, s)
+
+	body := "%PDF-1.4"
+	if err := s.Put(ctx, "typed.pdf", strings.NewReader(body), int64(len(body)), "application/pdf"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := s.client.StatObject(ctx, cfg.Bucket, "typed.pdf", minio.StatObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ContentType != "application/pdf" {
+		t.Errorf("content type = %q, want application/pdf", info.ContentType)
+	}
+}
+
+func TestNewS3RequiresEndpointAndBucket(t *testing.T) {
+	for _, cfg := range []S3Config{{Bucket: "b"}, {Endpoint: "localhost:9000"}} {
+		if _, err := NewS3(cfg); err == nil {
+			t.Errorf("NewS3(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
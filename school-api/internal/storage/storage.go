@@ -0,0 +1,84 @@
+// Package storage abstracts where uploaded files are kept.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"school-api/internal/config"
+)
+
+// ErrNotFound is returned by Get for a missing key.
+var ErrNotFound = errors.New("object not found")
+
+// Store is a blob store addressed by slash-separated keys.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Limits restrict what may be uploaded.
+type Limits struct {
+	MaxBytes     int64
+	AllowedTypes []string
+}
+
+// DefaultLimits allow documents and images up to 10 MiB.
+var DefaultLimits = Limits{
+	MaxBytes: 10 << 20,
+	AllowedTypes: []string{
+		"application/pdf",
+		"image/jpeg",
+		"image/png",
+		"text/plain",
+		"application/zip",
+		"application/msword",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.oasis.opendocument.text",
+	},
+}
+
+// Allows reports whether contentType (without parameters) is permitted.
+func (l Limits) Allows(contentType string) bool {
+	base, _, _ := strings.Cut(contentType, ";")
+	for _, t := range l.AllowedTypes {
+		if strings.EqualFold(strings.TrimSpace(base), t) {
+			return true
+		}
+	}
+	return false
+}
+
+// New builds the store selected by cfg.Backend ("local" or "s3") and the
+// upload limits. An empty type list keeps DefaultLimits.AllowedTypes.
+func New(cfg config.Storage) (Store, Limits, error) {
+	limits := DefaultLimits
+	if cfg.MaxUploadBytes > 0 {
+		limits.MaxBytes = cfg.MaxUploadBytes
+	}
+	if len(cfg.AllowedTypes) > 0 {
+		limits.AllowedTypes = cfg.AllowedTypes
+	}
+
+	switch cfg.Backend {
+	case "", "local":
+		s, err := NewLocal(cfg.Dir)
+		return s, limits, err
+	case "s3":
+		s, err := NewS3(S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			Bucket:    cfg.S3.Bucket,
+			Region:    cfg.S3.Region,
+			UseSSL:    cfg.S3.UseSSL,
+		})
+		return s, limits, err
+	default:
+		return nil, limits, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// testStore checks the behaviour every Store must share.
+func testStore(t *testing.T, s Store) {
+	ctx := context.Background()
+	const key = "homework/1/2/report.txt"
+
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get before Put: err = %v, want ErrNotFound", err)
+	}
+
+	body := "first version"
+	if err := s.Put(ctx, key, strings.NewReader(body), int64(len(body)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := read(t, s, key); got != body {
+		t.Errorf("Get = %q, want %q", got, body)
+	}
+
+	body = "second version"
+	if err := s.Put(ctx, key, strings.NewReader(body), int64(len(body)), "text/plain"); err != nil {
+		t.Fatalf("Put over existing key: %v", err)
+	}
+	if got := read(t, s, key); got != body {
+		t.Errorf("Get after overwrite = %q, want %q", got, body)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+}
+
+func read(t *testing.T, s Store, key string) string {
+	t.Helper()
+	rc, err := s.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get %s: %v", key, err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read %s: %v", key, err)
+	}
+	return string(b)
+}
+
+func TestLimitsAllows(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        bool
+	}{
+		{"application/pdf", true},
+		{"text/plain; charset=utf-8", true},
+		{"IMAGE/PNG", true},
+		{"text/html", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := DefaultLimits.Allows(tt.contentType); got != tt.want {
+			t.Errorf("Allows(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
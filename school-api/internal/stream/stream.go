@@ -0,0 +1,185 @@
+// Package stream pushes journal changes to browsers over Server-Sent
+// Events.
+//
+// Every API instance publishes its events with pg_notify and LISTENs on
+// the same channel, so a client connected to any instance sees changes
+// made through all of them.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+
+	"school-api/internal/events"
+	"school-api/internal/models"
+	"school-api/internal/tenant"
+)
+
+// Channel is the Postgres NOTIFY channel.
+const Channel = "journal_events"
+
+// maxPayload is kept below Postgres' 8000-byte NOTIFY limit.
+const maxPayload = 7900
+
+// Message is what clients receive. SchoolID and ClassID are used for
+// filtering.
+type Message struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	SchoolID uint            `json:"school_id"`
+	ClassID  uint            `json:"class_id"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// Hub fans messages out to connected clients of this instance.
+type Hub struct {
+	mu      sync.Mutex
+	clients map[chan Message]filter
+	done    chan struct{}
+	once    sync.Once
+}
+
+func NewHub() *Hub {
+	return &Hub{clients: map[chan Message]filter{}, done: make(chan struct{})}
+}
+
+// Close tells connected clients to end their streams, so that a shutting
+// down server is not held open by them.
+func (h *Hub) Close() {
+	h.once.Do(func() { close(h.done) })
+}
+
+// Done is closed by Close.
+func (h *Hub) Done() <-chan struct{} {
+	return h.done
+}
+
+// filter selects the messages of a client: those of its school, limited to
+// one class unless classID is 0.
+type filter struct {
+	schoolID, classID uint
+}
+
+// Subscribe registers a client of schoolID interested in classID (0 for
+// every class). The returned cancel function must be called when the
+// client leaves.
+func (h *Hub) Subscribe(schoolID, classID uint) (<-chan Message, func()) {
+	ch := make(chan Message, 32)
+	h.mu.Lock()
+	h.clients[ch] = filter{schoolID: schoolID, classID: classID}
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.clients, ch)
+		h.mu.Unlock()
+	}
+}
+
+// Broadcast delivers m to matching clients. Slow clients whose buffer is
+// full miss the message rather than blocking everyone else.
+func (h *Hub) Broadcast(m Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch, f := range h.clients {
+		if f.schoolID != m.SchoolID || (f.classID != 0 && f.classID != m.ClassID) {
+			continue
+		}
+		select {
+		case ch <- m:
+		default:
+		}
+	}
+}
+
+// streamed reports whether an event type is pushed to clients.
+func streamed(typ string) bool {
+	return strings.HasPrefix(typ, "student_lesson.") || strings.HasPrefix(typ, "lesson_log.")
+}
+
+// Notifier returns an events.Subscriber that forwards streamed events to
+// all instances via pg_notify.
+func Notifier(db *gorm.DB) events.Subscriber {
+	return func(ctx context.Context, e events.Event) {
+		if !streamed(e.Type) {
+			return
+		}
+		m := Message{ID: e.ID, Type: e.Type}
+		m.SchoolID, _ = tenant.FromContext(ctx)
+		switch d := e.Data.(type) {
+		case events.StudentLessonData:
+			m.ClassID = d.ClassID
+		case models.LessonLog:
+			m.ClassID = d.ClassID
+		}
+		data, err := json.Marshal(e.Data)
+		if err != nil {
+			slog.Error("stream: marshal failed", "event", e.Type, "error", err)
+			return
+		}
+		m.Data = data
+		payload, err := json.Marshal(m)
+		if err != nil {
+			return
+		}
+		if len(payload) > maxPayload {
+			// Too big for NOTIFY: send the envelope only, clients refetch.
+			m.Data = nil
+			payload, _ = json.Marshal(m)
+		}
+		if err := db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", Channel, string(payload)).Error; err != nil {
+			slog.Error("stream: notify failed", "event", e.Type, "error", err)
+		}
+	}
+}
+
+// Listen holds a dedicated connection LISTENing on Channel and broadcasts
+// every notification to hub until ctx is cancelled, reconnecting with
+// backoff when the connection drops.
+func Listen(ctx context.Context, dsn string, hub *Hub) {
+	backoff := time.Second
+	for ctx.Err() == nil {
+		err := listenOnce(ctx, dsn, hub)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("stream: listener stopped, reconnecting", "error", err, "backoff", backoff.String())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func listenOnce(ctx context.Context, dsn string, hub *Hub) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+Channel); err != nil {
+		return err
+	}
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var m Message
+		if err := json.Unmarshal([]byte(n.Payload), &m); err != nil {
+			slog.Warn("stream: bad payload", "error", err)
+			continue
+		}
+		hub.Broadcast(m)
+	}
+}
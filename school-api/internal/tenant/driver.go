@@ -0,0 +1,137 @@
+package tenant
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Setting is the Postgres setting row-level security policies compare
+// school_id with. Empty matches no rows; AllValue matches every row.
+const Setting = "app.school_id"
+
+// AllValue is the Setting of statements run under AllSchools.
+const AllValue = "all"
+
+const setSQL = "SELECT set_config('" + Setting + "', $1, false)"
+
+// Connector wraps a driver connector so that each statement runs with
+// app.school_id set to the school in its context, AllValue under
+// AllSchools, or empty. The value is kept per connection and only changed
+// when the next statement needs another one, so consecutive statements of
+// one school cost no extra round trip.
+func Connector(c driver.Connector) driver.Connector {
+	return connector{c}
+}
+
+type connector struct{ driver.Connector }
+
+// driverConn is what pgx's stdlib connections implement; the wrapper must
+// offer the same or database/sql would fall back to slower paths.
+type driverConn interface {
+	driver.Conn
+	driver.ConnPrepareContext
+	driver.ConnBeginTx
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.NamedValueChecker
+	driver.SessionResetter
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	dc, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inner, ok := dc.(driverConn)
+	if !ok {
+		dc.Close()
+		return nil, fmt.Errorf("tenant: unsupported driver connection %T", dc)
+	}
+	return &conn{driverConn: inner}, nil
+}
+
+// conn remembers the app.school_id value of its session. database/sql
+// never uses a connection from two goroutines at once, so no locking is
+// needed.
+type conn struct {
+	driverConn
+	school string
+	known  bool // false until set, and after a rollback may have undone it
+}
+
+// use sets app.school_id for the school in ctx unless already set.
+func (c *conn) use(ctx context.Context) error {
+	var want string
+	if id, ok := FromContext(ctx); ok {
+		want = strconv.FormatUint(uint64(id), 10)
+	} else if allSchools(ctx) {
+		want = AllValue
+	}
+	if c.known && c.school == want {
+		return nil
+	}
+	c.known = false
+	if _, err := c.driverConn.ExecContext(ctx, setSQL, []driver.NamedValue{{Ordinal: 1, Value: want}}); err != nil {
+		return err
+	}
+	c.school, c.known = want, true
+	return nil
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.use(ctx); err != nil {
+		return nil, err
+	}
+	return c.driverConn.PrepareContext(ctx, query)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.use(ctx); err != nil {
+		return nil, err
+	}
+	res, err := c.driverConn.ExecContext(ctx, query, args)
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(query)), "ROLLBACK") {
+		// ROLLBACK TO SAVEPOINT also undoes set_config since the savepoint.
+		c.known = false
+	}
+	return res, err
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.use(ctx); err != nil {
+		return nil, err
+	}
+	return c.driverConn.QueryContext(ctx, query, args)
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := c.driverConn.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &connTx{Tx: tx, conn: c}, nil
+}
+
+// connTx forgets the connection's setting when the transaction is rolled
+// back, which restores the value from before it.
+type connTx struct {
+	driver.Tx
+	conn *conn
+}
+
+func (t *connTx) Commit() error {
+	err := t.Tx.Commit()
+	if err != nil {
+		t.conn.known = false
+	}
+	return err
+}
+
+func (t *connTx) Rollback() error {
+	t.conn.known = false
+	return t.Tx.Rollback()
+}
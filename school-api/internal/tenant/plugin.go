@@ -0,0 +1,78 @@
+package tenant
+
+import (
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Plugin is a gorm plugin scoping statements on models with a SchoolID
+// field to the school in their context. Raw SQL is left to row-level
+// security, or to Scope.
+type Plugin struct{}
+
+func (Plugin) Name() string { return "tenant" }
+
+func (Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tenant:create", assign),
+		cb.Query().Before("gorm:query").Register("tenant:query", restrict),
+		cb.Update().Before("gorm:update").Register("tenant:update", func(db *gorm.DB) {
+			assign(db)
+			restrict(db)
+		}),
+		cb.Delete().Before("gorm:delete").Register("tenant:delete", restrict),
+		cb.Row().Before("gorm:row").Register("tenant:row", restrict),
+	)
+}
+
+// schoolField returns the SchoolID field of the statement's model and the
+// school of its context, ok=false when either is missing.
+func schoolField(db *gorm.DB) (*schema.Field, uint, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, 0, false
+	}
+	id, ok := FromContext(db.Statement.Context)
+	if !ok {
+		return nil, 0, false
+	}
+	f := db.Statement.Schema.LookUpField("SchoolID")
+	return f, id, f != nil
+}
+
+// restrict adds "school_id = ?" to the statement's WHERE clause.
+func restrict(db *gorm.DB) {
+	f, id, ok := schoolField(db)
+	if !ok || db.Statement.SQL.Len() > 0 {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: id},
+	}})
+}
+
+// assign sets SchoolID on the rows being written, overriding whatever the
+// caller sent.
+func assign(db *gorm.DB) {
+	f, id, ok := schoolField(db)
+	if !ok {
+		return
+	}
+	set := func(v reflect.Value) {
+		if v.Kind() == reflect.Struct && v.CanAddr() {
+			db.AddError(f.Set(db.Statement.Context, v, id))
+		}
+	}
+	switch rv := db.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			set(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		set(rv)
+	}
+}
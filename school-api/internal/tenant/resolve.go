@@ -0,0 +1,120 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"school-api/internal/auth"
+	"school-api/internal/logging"
+	"school-api/internal/models"
+)
+
+// Resolution failures.
+var (
+	ErrUnknownSchool = errors.New("unknown school")
+	ErrWrongSchool   = errors.New("credentials belong to another school")
+	ErrNoSchool      = errors.New("school could not be determined")
+)
+
+// Resolver finds the school a request is for.
+type Resolver struct {
+	DB *gorm.DB
+	// BaseDomain turns on subdomain routing: <slug>.<BaseDomain> is the
+	// school with that slug. Empty ignores the host.
+	BaseDomain string
+	// Default is the school of requests naming none, and of tokens issued
+	// without a school; 0 rejects them.
+	Default uint
+}
+
+// Resolve returns the school of a caller presenting p (nil when
+// anonymous) on host. The school of the credentials wins; a subdomain
+// naming another school is refused.
+func (r Resolver) Resolve(ctx context.Context, p *auth.Principal, host string) (uint, error) {
+	var fromHost uint
+	if slug := r.slug(host); slug != "" {
+		var s models.School
+		err := r.DB.WithContext(ctx).Select("id").Where("slug = ?", slug).Take(&s).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrUnknownSchool
+		}
+		if err != nil {
+			return 0, err
+		}
+		fromHost = s.ID
+	}
+	school := fromHost
+	if p != nil {
+		own := p.SchoolID
+		if own == 0 {
+			own = r.Default
+		}
+		if own == 0 {
+			return 0, ErrNoSchool
+		}
+		if fromHost != 0 && fromHost != own {
+			return 0, ErrWrongSchool
+		}
+		school = own
+	}
+	if school == 0 {
+		school = r.Default
+	}
+	if school == 0 {
+		return 0, ErrNoSchool
+	}
+	return school, nil
+}
+
+// slug returns the subdomain label of host under BaseDomain, or "".
+func (r Resolver) slug(host string) string {
+	if r.BaseDomain == "" {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	slug, ok := strings.CutSuffix(host, "."+strings.ToLower(r.BaseDomain))
+	if !ok {
+		return ""
+	}
+	return slug
+}
+
+// Middleware stores the request's school for FromContext. It runs after
+// authentication so that the token's school is known.
+func (r Resolver) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var p *auth.Principal
+		if v, ok := auth.FromContext(c); ok {
+			p = &v
+		}
+		id, err := r.Resolve(c.Request.Context(), p, c.Request.Host)
+		if err != nil {
+			status, code := http.StatusInternalServerError, "InternalServerError"
+			message := "Internal server error"
+			switch {
+			case errors.Is(err, ErrUnknownSchool):
+				status, code, message = http.StatusNotFound, "NotFound", "Unknown school"
+			case errors.Is(err, ErrWrongSchool):
+				status, code, message = http.StatusForbidden, "Forbidden", "Credentials belong to another school"
+			case errors.Is(err, ErrNoSchool):
+				status, code, message = http.StatusBadRequest, "BadRequest", "Cannot tell which school the request is for"
+			default:
+				slog.ErrorContext(c.Request.Context(), "school lookup failed", "error", err)
+			}
+			c.AbortWithStatusJSON(status, gin.H{"error": code, "message": message, "request_id": logging.RequestID(c.Request.Context())})
+			return
+		}
+		c.Request = c.Request.WithContext(WithSchool(c.Request.Context(), id))
+		c.Next()
+	}
+}
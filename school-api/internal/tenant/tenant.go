@@ -0,0 +1,48 @@
+// Package tenant isolates schools from each other.
+//
+// A request's school is resolved from the caller's token or the subdomain
+// and stored in its context with WithSchool. Two independent layers then
+// keep every statement run with that context inside the school:
+//
+//   - Plugin, a gorm plugin, adds "school_id = ?" to queries, updates and
+//     deletes of tenant models and sets SchoolID on the rows it creates;
+//   - Connector makes every connection carry the school in the
+//     app.school_id setting, which Postgres row-level security policies
+//     check (see migrations 0008 to 0010), so raw SQL is covered as well.
+//
+// Foreign keys between tenant tables include school_id (migration 0011),
+// since Postgres checks them without row-level security.
+//
+// Statements whose context has no school see no tenant rows and cannot
+// write any. Code that works across schools on purpose (migrations,
+// background workers, API key lookup) says so with AllSchools.
+package tenant
+
+import "context"
+
+type ctxKey struct{}
+
+type allKey struct{}
+
+// AllSchools returns a context whose statements may read and write the
+// rows of every school.
+func AllSchools(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allKey{}, true)
+}
+
+// allSchools reports whether ctx was marked by AllSchools.
+func allSchools(ctx context.Context) bool {
+	all, _ := ctx.Value(allKey{}).(bool)
+	return all
+}
+
+// WithSchool returns a context whose statements are limited to school id.
+func WithSchool(ctx context.Context, id uint) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the school set by WithSchool.
+func FromContext(ctx context.Context) (uint, bool) {
+	id, ok := ctx.Value(ctxKey{}).(uint)
+	return id, ok && id != 0
+}
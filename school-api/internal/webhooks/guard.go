@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned for a destination inside the server's own
+// network: loopback, private, link-local (cloud metadata at 169.254.169.254)
+// and other non-public ranges, unless an allowed network covers it.
+var ErrForbiddenAddress = errors.New("webhook destination is not a public address")
+
+// reserved are non-public IPv4 ranges the netip predicates do not cover.
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// Allowed reports whether deliveries may go to ip: public unicast
+// addresses always may, any other only inside one of allow.
+func Allowed(ip netip.Addr, allow []netip.Prefix) bool {
+	ip = ip.Unmap()
+	for _, p := range allow {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, p := range reserved {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewClient returns the delivery HTTP client. It checks every address it
+// connects to, after DNS resolution, so neither a redirect nor a name that
+// resolves to an internal address once the subscription is saved can
+// reach a destination Allowed rejects. Proxies are not used: the check
+// would only see the proxy.
+func NewClient(timeout time.Duration, allow []netip.Prefix) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addr, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !Allowed(addr.Addr(), allow) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr.Addr())
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestAllowed(t *testing.T) {
+	school := []netip.Prefix{netip.MustParsePrefix("10.20.0.0/16")}
+	tests := []struct {
+		ip    string
+		allow []netip.Prefix
+		want  bool
+	}{
+		{"93.184.216.34", nil, true},
+		{"2606:2800:220:1::", nil, true},
+		{"127.0.0.1", nil, false},
+		{"::1", nil, false},
+		{"::ffff:127.0.0.1", nil, false},
+		{"169.254.169.254", nil, false},
+		{"fe80::1", nil, false},
+		{"10.1.2.3", nil, false},
+		{"172.16.0.1", nil, false},
+		{"192.168.1.1", nil, false},
+		{"fd00::1", nil, false},
+		{"100.100.100.200", nil, false},
+		{"0.0.0.0", nil, false},
+		{"224.0.0.1", nil, false},
+		{"10.20.3.4", school, true},
+		{"10.21.3.4", school, false},
+	}
+	for _, tt := range tests {
+		if got := Allowed(netip.MustParseAddr(tt.ip), tt.allow); got != tt.want {
+			t.Errorf("Allowed(%s, %v) = %v, want %v", tt.ip, tt.allow, got, tt.want)
+		}
+	}
+}
+
+func TestClientRefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	_, err := NewClient(time.Second, nil).Get(srv.URL)
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("Get(%s) error = %v, want ErrForbiddenAddress", srv.URL, err)
+	}
+
+	// An allowed receiver redirecting elsewhere is refused at the
+	// second connection.
+	l, err := net.Listen("tcp", "127.0.0.2:0")
+	if err != nil {
+		t.Skipf("no second loopback address: %v", err)
+	}
+	inside := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	inside.Listener.Close()
+	inside.Listener = l
+	inside.Start()
+	defer inside.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(inside.URL, http.StatusFound))
+	defer redirect.Close()
+
+	client := NewClient(time.Second, []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")})
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get(%s) with 127.0.0.1 allowed: %v", srv.URL, err)
+	}
+	resp.Body.Close()
+	if _, err := client.Get(redirect.URL); !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("Get(%s) redirecting to %s: error = %v, want ErrForbiddenAddress", redirect.URL, inside.URL, err)
+	}
+}
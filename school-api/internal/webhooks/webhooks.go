@@ -0,0 +1,256 @@
+// Package webhooks delivers journal events to subscribed HTTP endpoints.
+//
+// Events are written to webhook_deliveries (an outbox) when published and
+// sent by a background worker, so slow or failing receivers never block
+// API requests. Failed deliveries are retried with exponential backoff.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"school-api/internal/events"
+	"school-api/internal/models"
+	"school-api/internal/tenant"
+)
+
+// Headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderID        = "X-Webhook-ID"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Dispatcher queues and sends webhook deliveries.
+type Dispatcher struct {
+	DB          *gorm.DB
+	Client      *http.Client
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Interval    time.Duration // how often the worker polls for due deliveries
+	BatchSize   int
+}
+
+// NewDispatcher returns a dispatcher with sensible defaults: 8 attempts
+// spread over about an hour. Deliveries go to public addresses and to the
+// allow networks only (see NewClient).
+func NewDispatcher(db *gorm.DB, allow []netip.Prefix) *Dispatcher {
+	return &Dispatcher{
+		DB:          db,
+		Client:      NewClient(10*time.Second, allow),
+		MaxAttempts: 8,
+		BaseBackoff: 30 * time.Second,
+		MaxBackoff:  time.Hour,
+		Interval:    2 * time.Second,
+		BatchSize:   20,
+	}
+}
+
+// Enqueue is an events.Subscriber that stores a pending delivery for every
+// active subscription interested in e.
+func (d *Dispatcher) Enqueue(ctx context.Context, e events.Event) {
+	var subs []models.WebhookSubscription
+	if err := d.DB.WithContext(ctx).Where("active = ?", true).Find(&subs).Error; err != nil {
+		slog.Error("webhooks: load subscriptions failed", "error", err)
+		return
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		slog.Error("webhooks: marshal failed", "event", e.Type, "error", err)
+		return
+	}
+	now := time.Now()
+	var rows []models.WebhookDelivery
+	for _, s := range subs {
+		if !Matches(s.EventTypes, e.Type) {
+			continue
+		}
+		rows = append(rows, models.WebhookDelivery{
+			SubscriptionID: s.ID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Payload:        string(payload),
+			Status:         models.DeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+	if len(rows) == 0 {
+		return
+	}
+	if err := d.DB.WithContext(ctx).Create(&rows).Error; err != nil {
+		slog.Error("webhooks: enqueue failed", "event", e.Type, "error", err)
+	}
+}
+
+// Matches reports whether a comma-separated subscription list includes typ.
+func Matches(list, typ string) bool {
+	for _, t := range strings.Split(list, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Run sends due deliveries of all schools until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ctx = tenant.AllSchools(ctx)
+	ticker := time.NewTicker(d.Interval)
+	defer ticker.Stop()
+	for {
+		for d.processBatch(ctx) > 0 {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// claimLease is how long a claimed delivery is hidden from other workers
+// while it is being sent.
+const claimLease = 5 * time.Minute
+
+// processBatch claims and sends up to BatchSize due deliveries and returns
+// how many were processed. Claiming locks rows with SKIP LOCKED and pushes
+// their next attempt past the lease, so several API instances can run
+// workers concurrently and a crashed worker's rows are retried later.
+func (d *Dispatcher) processBatch(ctx context.Context) int {
+	var batch []models.WebhookDelivery
+	err := d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, time.Now()).
+			Order("next_attempt_at").Limit(d.BatchSize).Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		ids := make([]uint, len(batch))
+		for i, del := range batch {
+			ids[i] = del.ID
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).
+			Update("next_attempt_at", time.Now().Add(claimLease)).Error
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("webhooks: claim deliveries failed", "error", err)
+		}
+		return 0
+	}
+	for i := range batch {
+		// On shutdown the rest of the batch is left to be retried once
+		// its lease expires.
+		if ctx.Err() != nil {
+			return i
+		}
+		d.attempt(ctx, &batch[i])
+		if err := d.DB.WithContext(context.WithoutCancel(ctx)).Save(&batch[i]).Error; err != nil {
+			slog.Error("webhooks: save delivery failed", "delivery_id", batch[i].ID, "error", err)
+		}
+	}
+	return len(batch)
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, del *models.WebhookDelivery) {
+	del.Attempts++
+	var sub models.WebhookSubscription
+	err := d.DB.WithContext(ctx).First(&sub, del.SubscriptionID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		del.Status = models.DeliveryFailed
+		del.LastError = "subscription no longer exists"
+		return
+	}
+	status := 0
+	if err == nil {
+		status, err = d.send(ctx, sub, del)
+	}
+	del.ResponseStatus = status
+	if err == nil {
+		now := time.Now()
+		del.Status = models.DeliverySucceeded
+		del.LastError = ""
+		del.DeliveredAt = &now
+		return
+	}
+	del.LastError = err.Error()
+	if del.Attempts >= d.MaxAttempts {
+		del.Status = models.DeliveryFailed
+		return
+	}
+	del.NextAttemptAt = time.Now().Add(d.backoff(del.Attempts))
+}
+
+// backoff returns the delay before the next attempt: BaseBackoff doubled
+// for each attempt made so far, capped at MaxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.BaseBackoff
+	for i := 1; i < attempts && delay < d.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.MaxBackoff {
+		delay = d.MaxBackoff
+	}
+	return delay
+}
+
+func (d *Dispatcher) send(ctx context.Context, sub models.WebhookSubscription, del *models.WebhookDelivery) (int, error) {
+	body := []byte(del.Payload)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "school-api-webhooks/1")
+	req.Header.Set(HeaderEvent, del.EventType)
+	req.Header.Set(HeaderID, del.EventID)
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(sub.Secret, ts, body))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign computes the hex HMAC-SHA256 of "<timestamp>.<body>" with secret.
+// Receivers recompute it to verify the payload and reject stale timestamps
+// to prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -10,6 +10,8 @@ $env:DB_PASSWORD="pass"
 $env:DB_NAME="SportRental"
 $env:DB_SSLMODE="disable"
 $env:PORT="8000"
+# Signs bearer tokens (16+ characters); for local development only
+if (-not $env:AUTH_SECRET) { $env:AUTH_SECRET="local-development-secret" }
 
 Start-Process -FilePath "powershell" -ArgumentList "-Command", "cd school-api; ./school-api" -WindowStyle Minimized
 